@@ -7,8 +7,10 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
 
 	"github.com/cockroachdb/apd/v3"
@@ -59,7 +61,45 @@ func (e MismatchError) Error() string {
 	return fmt.Sprintf("amounts %q and %q have mismatched currency codes", e.A, e.B)
 }
 
+// ArithmeticError is returned when a calculation can't be completed,
+// e.g. because its result has too many digits to be represented.
+type ArithmeticError struct {
+	Op  string
+	Err error
+}
+
+func (e ArithmeticError) Error() string {
+	return fmt.Sprintf("arithmetic error in %v: %v", e.Op, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e ArithmeticError) Unwrap() error {
+	return e.Err
+}
+
+// ErrEmptyAmount is returned when parsing an empty or whitespace-only string.
+var ErrEmptyAmount = errors.New("empty amount")
+
+// ErrInvalidRange is returned when the minimum of a range is greater than its maximum.
+var ErrInvalidRange = errors.New("invalid range: min is greater than max")
+
+// ErrNoAmounts is returned when no amounts are given, or all of them are the zero value.
+var ErrNoAmounts = errors.New("no amounts")
+
 // Amount stores a decimal number with its currency code.
+//
+// The number keeps its scale, so "10.5" and "10.50" have different String()
+// and Number() representations. Use Equal or Cmp to compare amounts by value,
+// instead of comparing strings or using the == operator.
+//
+// Add, Sub, Mul and Convert are exact, and rounding (RoundTo, QuantizeTo)
+// only drops the requested digits: the precision is raised as needed to
+// hold every digit of the result. Div rounds to 19 significant digits
+// (39 for larger operands), since its result can be non-terminating.
+//
+// Amounts are immutable: every method returns a new Amount instead of
+// modifying its receiver or arguments, so an Amount can be freely copied,
+// stored and shared between goroutines.
 type Amount struct {
 	number       apd.Decimal
 	currencyCode string
@@ -78,6 +118,56 @@ func NewAmount(n, currencyCode string) (Amount, error) {
 	return Amount{number, currencyCode}, nil
 }
 
+// NewAmountForLocale creates a new Amount from a numeric string,
+// using the currency of the locale's territory.
+//
+// For example, "de-CH" uses CHF, while "de" has no territory and fails.
+// Returns InvalidCurrencyCodeError with an empty currency code
+// when the locale has no territory, or the territory has no currency.
+func NewAmountForLocale(n string, locale Locale) (Amount, error) {
+	currencyCode, ok := ForCountryCode(locale.Territory)
+	if !ok {
+		number := apd.Decimal{}
+		if _, _, err := number.SetString(n); err != nil {
+			return Amount{}, InvalidNumberError{n}
+		}
+		return Amount{}, InvalidCurrencyCodeError{""}
+	}
+
+	return NewAmount(n, currencyCode)
+}
+
+// NewAmountPermissive creates a new Amount from a numeric string and a currency code,
+// allowing unknown currency codes.
+//
+// Meant for ingesting third-party data which might contain currencies
+// unknown to this package. Any code consisting of 3 uppercase letters
+// is accepted. Unknown currencies are assumed to have 2 fraction digits,
+// and are formatted using the currency code as the symbol.
+// Note that amounts with unknown currency codes can't be unmarshaled
+// or scanned, since those operations validate the currency code.
+func NewAmountPermissive(n, currencyCode string) (Amount, error) {
+	number := apd.Decimal{}
+	if _, _, err := number.SetString(n); err != nil {
+		return Amount{}, InvalidNumberError{n}
+	}
+	if currencyCode == "" || (!IsValid(currencyCode) && !isWellFormed(currencyCode)) {
+		return Amount{}, InvalidCurrencyCodeError{currencyCode}
+	}
+
+	return Amount{number, currencyCode}, nil
+}
+
+// MustNewAmount is like NewAmount but panics if the amount cannot be created.
+// It simplifies safe initialization of amounts from known-good values.
+func MustNewAmount(n, currencyCode string) Amount {
+	a, err := NewAmount(n, currencyCode)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
 // NewAmountFromBigInt creates a new Amount from a big.Int and a currency code.
 func NewAmountFromBigInt(n *big.Int, currencyCode string) (Amount, error) {
 	if n == nil {
@@ -93,6 +183,15 @@ func NewAmountFromBigInt(n *big.Int, currencyCode string) (Amount, error) {
 	return Amount{*number, currencyCode}, nil
 }
 
+// MustNewAmountFromBigInt is like NewAmountFromBigInt but panics if the amount cannot be created.
+func MustNewAmountFromBigInt(n *big.Int, currencyCode string) Amount {
+	a, err := NewAmountFromBigInt(n, currencyCode)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
 // NewAmountFromInt64 creates a new Amount from an int64 and a currency code.
 func NewAmountFromInt64(n int64, currencyCode string) (Amount, error) {
 	d, ok := GetDigits(currencyCode)
@@ -105,9 +204,50 @@ func NewAmountFromInt64(n int64, currencyCode string) (Amount, error) {
 	return Amount{number, currencyCode}, nil
 }
 
+// MustNewAmountFromInt64 is like NewAmountFromInt64 but panics if the amount cannot be created.
+func MustNewAmountFromInt64(n int64, currencyCode string) Amount {
+	a, err := NewAmountFromInt64(n, currencyCode)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// NewAmountFromMinorUnits creates a new Amount from an int64 with the given scale.
+//
+// The amount is units × 10^-scale, regardless of the currency's number of
+// fraction digits. For example, 123456 at scale 4 is "12.3456 USD".
+// Meant for ledgers which store amounts with more precision than ISO 4217
+// allows. Use NewAmountFromInt64 to use the currency's fraction digits.
+func NewAmountFromMinorUnits(units int64, scale uint8, currencyCode string) (Amount, error) {
+	if currencyCode == "" || !IsValid(currencyCode) {
+		return Amount{}, InvalidCurrencyCodeError{currencyCode}
+	}
+	number := apd.Decimal{}
+	number.SetFinite(units, -int32(scale))
+
+	return Amount{number, currencyCode}, nil
+}
+
+// Copy returns a copy of a with its own copy of the underlying number.
+//
+// Since amounts are immutable, a plain assignment is already safe,
+// Copy is only needed when a guaranteed-independent value is wanted.
+func (a Amount) Copy() Amount {
+	result := apd.Decimal{}
+	result.Set(&a.number)
+
+	return Amount{result, a.currencyCode}
+}
+
 // Number returns the number as a numeric string.
+//
+// The number is never in scientific notation: "1E-8" is returned as
+// "0.00000001", and "1E+3" as "1000". This also applies to String(),
+// MarshalJSON and Value. Note that a number with a large exponent
+// (e.g. "1e99999") results in a correspondingly long string.
 func (a Amount) Number() string {
-	return a.number.String()
+	return a.number.Text('f')
 }
 
 // CurrencyCode returns the currency code.
@@ -120,6 +260,55 @@ func (a Amount) String() string {
 	return a.Number() + " " + a.CurrencyCode()
 }
 
+// StringCodeFirst returns the string representation of a,
+// with the currency code first (e.g. "USD 10.99").
+func (a Amount) StringCodeFirst() string {
+	return a.CurrencyCode() + " " + a.Number()
+}
+
+// StringGrouped returns the string representation of a, with the major
+// digits grouped by thousands (e.g. "1,234,567.99 USD").
+//
+// Meant for logs and debug output, the grouping is the same for all locales.
+// Use String for machine-readable output, and a Formatter for display.
+func (a Amount) StringGrouped() string {
+	n := a.Number()
+	sign := ""
+	if strings.HasPrefix(n, "-") {
+		sign, n = "-", n[1:]
+	}
+	major, minor := n, ""
+	if i := strings.IndexByte(n, '.'); i != -1 {
+		major, minor = n[:i], n[i:]
+	}
+	b := strings.Builder{}
+	b.WriteString(sign)
+	for i := 0; i < len(major); i++ {
+		if i > 0 && (len(major)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte(major[i])
+	}
+	b.WriteString(minor)
+	b.WriteString(" ")
+	b.WriteString(a.currencyCode)
+
+	return b.String()
+}
+
+// Text returns the string representation of a, using the given number format.
+//
+// Supported formats:
+//
+//	'f' plain, e.g. "0.000000012 USD" (same as String)
+//	'e' or 'E' scientific, e.g. "1.2e-8 USD"
+//	'g' or 'G' 'e' for tiny numbers and positive exponents (e.g. "1e+3 USD"), 'f' otherwise
+//
+// Other formats are not supported.
+func (a Amount) Text(format byte) string {
+	return a.number.Text(format) + " " + a.currencyCode
+}
+
 // BigInt returns a in minor units, as a big.Int.
 func (a Amount) BigInt() *big.Int {
 	a = a.Round()
@@ -141,6 +330,21 @@ func (a Amount) Int64() (int64, error) {
 	return n.Int64()
 }
 
+// Float64 returns the nearest float64 value for a, and whether it is exact.
+//
+// Floats are not suitable for storing or calculating currency amounts,
+// this is meant for interoperability with numeric code (charts, statistics).
+func (a Amount) Float64() (f float64, exact bool) {
+	r, ok := new(big.Rat).SetString(a.Number())
+	if !ok {
+		// Infinity or NaN.
+		f, _ = a.number.Float64()
+		return f, false
+	}
+
+	return r.Float64()
+}
+
 // Convert converts a to a different currency.
 func (a Amount) Convert(currencyCode, rate string) (Amount, error) {
 	if currencyCode == "" || !IsValid(currencyCode) {
@@ -151,11 +355,82 @@ func (a Amount) Convert(currencyCode, rate string) (Amount, error) {
 		return Amount{}, InvalidNumberError{rate}
 	}
 	ctx := decimalContext(&a.number, &result)
+	ctx = exactContext(ctx, mulDigits(&a.number, &result))
 	ctx.Mul(&result, &a.number, &result)
 
 	return Amount{result, currencyCode}, nil
 }
 
+// ConvertInverse converts a to a different currency, using an inverse rate.
+//
+// The inverse rate is the amount of a's currency per unit of the target
+// currency, so a is divided by it. This avoids the precision loss of
+// calculating 1/rate and passing it to Convert (e.g. for a rate of "3").
+// Just like with Div, the result is rounded to 19 significant digits
+// (39 for larger operands) when it can't be exact.
+func (a Amount) ConvertInverse(currencyCode, inverseRate string) (Amount, error) {
+	if currencyCode == "" || !IsValid(currencyCode) {
+		return Amount{}, InvalidCurrencyCodeError{currencyCode}
+	}
+	result := apd.Decimal{}
+	if _, _, err := result.SetString(inverseRate); err != nil {
+		return Amount{}, InvalidNumberError{inverseRate}
+	}
+	if result.IsZero() {
+		return Amount{}, InvalidNumberError{inverseRate}
+	}
+	ctx := decimalContext(&a.number, &result)
+	ctx.Quo(&result, &a.number, &result)
+	result.Reduce(&result)
+
+	return Amount{result, currencyCode}, nil
+}
+
+// StepMismatchError is returned when a conversion step doesn't start
+// from the currency reached by the previous step.
+type StepMismatchError struct {
+	// Expected is the currency code reached by the previous step.
+	Expected string
+	// Actual is the currency code the step starts from.
+	Actual string
+}
+
+func (e StepMismatchError) Error() string {
+	return fmt.Sprintf("conversion step from %q doesn't match the current currency %q", e.Actual, e.Expected)
+}
+
+// ConversionStep represents a single step in a chain of conversions.
+type ConversionStep struct {
+	// From is the currency code being converted from.
+	From string
+	// To is the currency code being converted to.
+	To string
+	// Rate is the exchange rate between the two currencies.
+	Rate string
+}
+
+// ConvertChain converts a through a chain of currencies (e.g. USD to EUR to RSD).
+//
+// Each step must start from the currency reached by the previous step,
+// the first one starting from a's currency, or StepMismatchError is returned.
+// Just like with Convert, the result is not rounded, avoiding rounding
+// drift between the steps.
+func (a Amount) ConvertChain(steps []ConversionStep) (Amount, error) {
+	result := a
+	for _, step := range steps {
+		if step.From != result.currencyCode {
+			return Amount{}, StepMismatchError{result.currencyCode, step.From}
+		}
+		var err error
+		result, err = result.Convert(step.To, step.Rate)
+		if err != nil {
+			return Amount{}, err
+		}
+	}
+
+	return result, nil
+}
+
 // Add adds a and b together and returns the result.
 func (a Amount) Add(b Amount) (Amount, error) {
 	if a.currencyCode != b.currencyCode {
@@ -169,6 +444,7 @@ func (a Amount) Add(b Amount) (Amount, error) {
 	}
 	result := apd.Decimal{}
 	ctx := decimalContext(&a.number, &b.number)
+	ctx = exactContext(ctx, addDigits(&a.number, &b.number))
 	ctx.Add(&result, &a.number, &b.number)
 
 	return Amount{result, a.currencyCode}, nil
@@ -190,11 +466,52 @@ func (a Amount) Sub(b Amount) (Amount, error) {
 	}
 	result := apd.Decimal{}
 	ctx := decimalContext(&a.number, &b.number)
+	ctx = exactContext(ctx, addDigits(&a.number, &b.number))
 	ctx.Sub(&result, &a.number, &b.number)
 
 	return Amount{result, a.currencyCode}, nil
 }
 
+// AddNumber adds n to a and returns the result.
+//
+// The number n is treated as an amount in a's currency,
+// e.g. "2.50" added to "20.99 USD" is "23.49 USD".
+func (a Amount) AddNumber(n string) (Amount, error) {
+	number := apd.Decimal{}
+	if _, _, err := number.SetString(n); err != nil {
+		return Amount{}, InvalidNumberError{n}
+	}
+
+	return a.Add(Amount{number, a.currencyCode})
+}
+
+// SubNumber subtracts n from a and returns the result.
+//
+// The number n is treated as an amount in a's currency,
+// e.g. "2.50" subtracted from "20.99 USD" is "18.49 USD".
+func (a Amount) SubNumber(n string) (Amount, error) {
+	number := apd.Decimal{}
+	if _, _, err := number.SetString(n); err != nil {
+		return Amount{}, InvalidNumberError{n}
+	}
+
+	return a.Sub(Amount{number, a.currencyCode})
+}
+
+// NegateIf returns -a if cond is true, and a otherwise.
+//
+// Useful for flipping signs in ledgers, e.g. amount.NegateIf(isCredit).
+// Zero amounts, including the zero value, are returned unchanged.
+func (a Amount) NegateIf(cond bool) Amount {
+	if !cond {
+		return a
+	}
+	result := apd.Decimal{}
+	result.Neg(&a.number)
+
+	return Amount{result, a.currencyCode}
+}
+
 // Mul multiplies a by n and returns the result.
 func (a Amount) Mul(n string) (Amount, error) {
 	result := apd.Decimal{}
@@ -202,12 +519,73 @@ func (a Amount) Mul(n string) (Amount, error) {
 		return Amount{}, InvalidNumberError{n}
 	}
 	ctx := decimalContext(&a.number, &result)
+	ctx = exactContext(ctx, mulDigits(&a.number, &result))
 	ctx.Mul(&result, &a.number, &result)
 
 	return Amount{result, a.currencyCode}, nil
 }
 
+// MulRounded multiplies a by n, and rounds the result to the currency's digits.
+//
+// For example, "20.99 USD" multiplied by "0.20" is "4.20 USD" (RoundHalfUp),
+// instead of "4.1980 USD".
+func (a Amount) MulRounded(n string, mode RoundingMode) (Amount, error) {
+	result, err := a.Mul(n)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	return result.RoundToCurrencyDigits(mode), nil
+}
+
+// ApplyDiscount applies a promotional discount to a.
+//
+// The discount is percent of a (e.g. "10" for 10%) plus the fixed number,
+// capped at maxDiscount (an empty maxDiscount means no cap), and never
+// larger than a itself, so that the final price can't go below zero.
+// Both the discount and the final price are rounded to the currency's
+// digits, with the discount rounded first and subtracted from the
+// rounded price, so that final + discount always equals a (rounded).
+// The percent, fixed and maxDiscount numbers must not be negative,
+// and neither must a.
+func (a Amount) ApplyDiscount(percent, fixed, maxDiscount string, mode RoundingMode) (final Amount, discount Amount, err error) {
+	if a.IsNegative() {
+		return Amount{}, Amount{}, InvalidNumberError{a.Number()}
+	}
+	numbers := []string{percent, fixed, maxDiscount}
+	decimals := make([]apd.Decimal, len(numbers))
+	for i, n := range numbers {
+		if i == 2 && n == "" {
+			continue
+		}
+		if _, _, err := decimals[i].SetString(n); err != nil || decimals[i].Sign() < 0 {
+			return Amount{}, Amount{}, InvalidNumberError{n}
+		}
+	}
+	// The numbers have been validated, so there can be no errors below.
+	discount, _ = a.Mul(percent)
+	discount = discount.Shift(-2)
+	discount, _ = discount.Add(Amount{decimals[1], a.currencyCode})
+	if maxDiscount != "" {
+		maxAmount := Amount{decimals[2], a.currencyCode}
+		if c, _ := discount.Cmp(maxAmount); c > 0 {
+			discount = maxAmount
+		}
+	}
+	price := a.RoundToCurrencyDigits(mode)
+	discount = discount.RoundToCurrencyDigits(mode)
+	if c, _ := discount.Cmp(price); c > 0 {
+		discount = price
+	}
+	final, _ = price.Sub(discount)
+
+	return final, discount, nil
+}
+
 // Div divides a by n and returns the result.
+//
+// Unlike Add, Sub and Mul, the result can't always be exact (e.g. 1/3),
+// so it is rounded to 19 significant digits (39 for larger operands).
 func (a Amount) Div(n string) (Amount, error) {
 	result := apd.Decimal{}
 	if _, _, err := result.SetString(n); err != nil {
@@ -223,24 +601,433 @@ func (a Amount) Div(n string) (Amount, error) {
 	return Amount{result, a.currencyCode}, nil
 }
 
+// PercentChange returns the percentage change from a to b.
+//
+// Computed as ((b - a) / a) * 100, so the sign follows a when a is negative.
+// For example, the change from "100.00" to "125.00" is "25".
+// The result is rounded to 19 significant digits (39 for larger amounts).
+func (a Amount) PercentChange(b Amount) (string, error) {
+	if a.currencyCode != b.currencyCode {
+		return "", MismatchError{a, b}
+	}
+	if a.IsZero() {
+		return "", InvalidNumberError{a.Number()}
+	}
+	result := apd.Decimal{}
+	ctx := decimalContext(&a.number, &b.number)
+	exactContext(ctx, addDigits(&a.number, &b.number)).Sub(&result, &b.number, &a.number)
+	ctx.Quo(&result, &result, &a.number)
+	ctx.Mul(&result, &result, apd.New(100, 0))
+	result.Reduce(&result)
+
+	return result.Text('f'), nil
+}
+
+// DivTo divides a by n and rounds the result to the given number of fraction digits.
+//
+// Passing currency.DefaultDigits (255) rounds to the currency's number
+// of fraction digits, like RoundTo.
+// Unlike Div followed by RoundTo, the quotient is only computed to
+// the digits needed for rounding, and is rounded exactly once.
+func (a Amount) DivTo(n string, digits uint8, mode RoundingMode) (Amount, error) {
+	if digits == DefaultDigits {
+		digits = getDigits(a.currencyCode)
+	}
+	result := apd.Decimal{}
+	if _, _, err := result.SetString(n); err != nil {
+		return Amount{}, InvalidNumberError{n}
+	}
+	if result.IsZero() {
+		return Amount{}, InvalidNumberError{n}
+	}
+	if err := quoRounded(&result, &a.number, &result, digits, mode); err != nil {
+		return Amount{}, ArithmeticError{"DivTo", err}
+	}
+
+	return Amount{result, a.currencyCode}, nil
+}
+
+// quoRounded sets result to x / y, rounded to the given number of fraction digits.
+//
+// The quotient is only computed to the digits needed for rounding,
+// and is rounded exactly once.
+func quoRounded(result, x, y *apd.Decimal, digits uint8, mode RoundingMode) error {
+	// Truncate the quotient two digits past the requested scale, and
+	// mark an inexact quotient with a trailing 1, so that it can't be
+	// mistaken for an exact tie (or an exact value) when rounding.
+	intDigits := x.NumDigits() + int64(x.Exponent) - y.NumDigits() - int64(y.Exponent) + 1
+	precision := intDigits + int64(digits) + 2
+	if precision < 1 {
+		precision = 1
+	}
+	ctx := decimalContext(x, y).WithPrecision(uint32(precision))
+	ctx.Rounding = apd.RoundDown
+	cond, err := ctx.Quo(result, x, y)
+	if err != nil {
+		return err
+	}
+	if cond.Inexact() {
+		result.Coeff.Mul(&result.Coeff, apd.NewBigInt(10))
+		result.Coeff.Add(&result.Coeff, apd.NewBigInt(1))
+		result.Exponent--
+	}
+	if !result.IsZero() && result.NumDigits()+int64(result.Exponent) < -int64(digits) {
+		// Quantize drops values this small without rounding them, replace
+		// with one that rounds the same way (a tenth of the last digit).
+		result.Coeff.SetInt64(1)
+		result.Exponent = -int32(digits) - 1
+	}
+	ctx = exactContext(roundingContext(result, mode), quantizeDigits(result, -int32(digits)))
+	_, err = ctx.Quantize(result, result, -int32(digits))
+
+	return err
+}
+
+// Denominate breaks a down into the given denominations.
+//
+// Returns the number of each denomination needed, keyed by the
+// denomination number, and the remainder that couldn't be represented.
+// Uses a greedy algorithm (largest denomination first), which gives
+// the minimal count for canonical denomination sets (e.g. 1, 2, 5, 10).
+// All denominations must be positive and share a's currency code.
+func (a Amount) Denominate(denominations []Amount) (map[string]int, Amount, error) {
+	if a.IsNegative() {
+		return nil, Amount{}, InvalidNumberError{a.Number()}
+	}
+	sorted := make([]Amount, 0, len(denominations))
+	for _, d := range denominations {
+		if d.currencyCode != a.currencyCode {
+			return nil, Amount{}, MismatchError{a, d}
+		}
+		if !d.IsPositive() {
+			return nil, Amount{}, InvalidNumberError{d.Number()}
+		}
+		sorted = append(sorted, d)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].number.Cmp(&sorted[j].number) == 1
+	})
+
+	counts := make(map[string]int, len(sorted))
+	remainder := a.number
+	for _, d := range sorted {
+		count := apd.Decimal{}
+		ctx := decimalContext(&remainder, &d.number)
+		quoCtx := exactContext(ctx, quoIntegerDigits(&remainder, &d.number))
+		if _, err := quoCtx.QuoInteger(&count, &remainder, &d.number); err != nil {
+			return nil, Amount{}, ArithmeticError{"Denominate", err}
+		}
+		if count.IsZero() {
+			continue
+		}
+		n, err := count.Int64()
+		if err != nil {
+			return nil, Amount{}, ArithmeticError{"Denominate", err}
+		}
+		counts[d.Number()] += int(n)
+		used := apd.Decimal{}
+		exactContext(ctx, mulDigits(&count, &d.number)).Mul(&used, &count, &d.number)
+		exactContext(ctx, addDigits(&remainder, &used)).Sub(&remainder, &remainder, &used)
+	}
+
+	return counts, Amount{remainder, a.currencyCode}, nil
+}
+
+// AllocateProportional splits total into parts proportional to the given weights.
+//
+// Each part is in whole minor units (e.g. cents), and the parts always
+// add up to total, with any leftover minor units going to the parts
+// with the largest remainders. A zero weight always gets a zero part.
+// For example, allocating "10.00" by "1.00", "1.00", "1.00" gives
+// "3.34", "3.33", "3.33".
+// The weights must share total's currency code, must not be negative,
+// and must not all be zero. The total must not have more fraction digits
+// than the currency allows. A negative total results in negative parts.
+func AllocateProportional(total Amount, weights []Amount) ([]Amount, error) {
+	digits := getDigits(total.currencyCode)
+	units := apd.Decimal{}
+	units.Reduce(&total.number)
+	units.Exponent += int32(digits)
+	if units.Exponent < 0 {
+		return nil, InvalidNumberError{total.Number()}
+	}
+	minExponent := int32(0)
+	for _, w := range weights {
+		if w.currencyCode != total.currencyCode {
+			return nil, MismatchError{total, w}
+		}
+		if w.IsNegative() {
+			return nil, InvalidNumberError{w.Number()}
+		}
+		if w.number.Exponent < minExponent {
+			minExponent = w.number.Exponent
+		}
+	}
+	// Allocate integers, to keep the remainders exact.
+	unitsInt := scaleCoeff(&units, 0)
+	weightInts := make([]*apd.BigInt, len(weights))
+	sum := apd.NewBigInt(0)
+	for i, w := range weights {
+		weightInts[i] = scaleCoeff(&w.number, minExponent)
+		sum.Add(sum, weightInts[i])
+	}
+	if sum.Sign() == 0 {
+		return nil, InvalidNumberError{"0"}
+	}
+
+	parts := make([]*apd.BigInt, len(weights))
+	remainders := make([]*apd.BigInt, len(weights))
+	leftover := new(apd.BigInt).Set(unitsInt)
+	for i, w := range weightInts {
+		parts[i], remainders[i] = new(apd.BigInt), new(apd.BigInt)
+		parts[i].Mul(unitsInt, w)
+		parts[i].QuoRem(parts[i], sum, remainders[i])
+		leftover.Sub(leftover, parts[i])
+	}
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]].Cmp(remainders[order[j]]) == 1
+	})
+	// The leftover is smaller than the number of weights.
+	one := apd.NewBigInt(1)
+	for i := int64(0); i < leftover.Int64(); i++ {
+		parts[order[i]].Add(parts[order[i]], one)
+	}
+
+	amounts := make([]Amount, len(parts))
+	for i, part := range parts {
+		number := apd.Decimal{}
+		number.Coeff.Set(part)
+		number.Exponent = -int32(digits)
+		number.Negative = total.IsNegative() && part.Sign() != 0
+		amounts[i] = Amount{number, total.currencyCode}
+	}
+
+	return amounts, nil
+}
+
+// RoundPreservingSum rounds each amount to its currency digits, such that
+// the rounded amounts add up to target.
+//
+// Each amount is rounded down, and the remaining minor units are added
+// to the amounts which lost the most in rounding (largest remainder method).
+// For example, rounding "3.333", "3.333", "3.334" to a target of "10.00"
+// gives "3.33", "3.33", "3.34".
+// The amounts must share target's currency code. Returns an InvalidNumberError
+// if the target has too many fraction digits, or is unreachable: it must be
+// within one minor unit per amount of the sum of the rounded down amounts.
+func RoundPreservingSum(amounts []Amount, target Amount) ([]Amount, error) {
+	digits := getDigits(target.currencyCode)
+	rounded := make([]Amount, len(amounts))
+	remainders := make([]apd.Decimal, len(amounts))
+	sum := apd.Decimal{}
+	for i, a := range amounts {
+		if a.currencyCode != target.currencyCode {
+			return nil, MismatchError{target, a}
+		}
+		ctx := *exactContext(decimalContext(&a.number), quantizeDigits(&a.number, -int32(digits)))
+		ctx.Rounding = apd.RoundFloor
+		rounded[i].currencyCode = a.currencyCode
+		ctx.Quantize(&rounded[i].number, &a.number, -int32(digits))
+		ctx = *exactContext(decimalContext(&a.number), addDigits(&a.number, &rounded[i].number))
+		ctx.Sub(&remainders[i], &a.number, &rounded[i].number)
+		ctx = *exactContext(decimalContext(&sum), addDigits(&sum, &rounded[i].number))
+		ctx.Add(&sum, &sum, &rounded[i].number)
+	}
+	// Find the number of minor units missing from the sum.
+	leftover := apd.Decimal{}
+	ctx := exactContext(decimalContext(&target.number), addDigits(&target.number, &sum))
+	ctx.Sub(&leftover, &target.number, &sum)
+	leftover.Exponent += int32(digits)
+	leftover.Reduce(&leftover)
+	n, err := leftover.Int64()
+	if err != nil || n < 0 || n > int64(len(amounts)) {
+		return nil, InvalidNumberError{target.Number()}
+	}
+
+	order := make([]int, len(amounts))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]].Cmp(&remainders[order[j]]) == 1
+	})
+	unit := apd.New(1, -int32(digits))
+	for _, i := range order[:n] {
+		ctx := exactContext(decimalContext(&rounded[i].number), addDigits(&rounded[i].number, unit))
+		ctx.Add(&rounded[i].number, &rounded[i].number, unit)
+	}
+
+	return rounded, nil
+}
+
+// scaleCoeff returns the coefficient of d scaled to the given exponent.
+//
+// The exponent must not be greater than d's exponent.
+func scaleCoeff(d *apd.Decimal, exponent int32) *apd.BigInt {
+	m := apd.NewBigInt(10)
+	m.Exp(m, apd.NewBigInt(int64(d.Exponent-exponent)), nil)
+
+	return m.Mul(m, &d.Coeff)
+}
+
+// Shift multiplies a by 10^places and returns the result.
+//
+// Positive places shift the decimal point to the right (e.g. dollars to cents),
+// negative places shift it to the left. The result is exact: it is not
+// rounded, and the currency code is unchanged.
+func (a Amount) Shift(places int) Amount {
+	result := apd.Decimal{}
+	result.Set(&a.number)
+	result.Exponent += int32(places)
+	if result.Exponent > 0 {
+		// Move the exponent into the coefficient, to avoid
+		// having the number represented as "1.234E+5".
+		m := apd.NewBigInt(10)
+		m.Exp(m, apd.NewBigInt(int64(result.Exponent)), nil)
+		result.Coeff.Mul(&result.Coeff, m)
+		result.Exponent = 0
+	}
+
+	return Amount{result, a.currencyCode}
+}
+
 // Round is a shortcut for RoundTo(currency.DefaultDigits, currency.RoundHalfUp).
 func (a Amount) Round() Amount {
 	return a.RoundTo(DefaultDigits, RoundHalfUp)
 }
 
 // RoundTo rounds a to the given number of fraction digits.
+//
+// Passing currency.DefaultDigits (255) rounds to the currency's number
+// of fraction digits, just like RoundToCurrencyDigits. Rounding to
+// exactly 255 fraction digits is therefore not possible via RoundTo.
+//
+// The number of significant digits is not limited: the precision is
+// raised as needed, so amounts past 39 digits are rounded correctly.
 func (a Amount) RoundTo(digits uint8, mode RoundingMode) Amount {
 	if digits == DefaultDigits {
-		digits, _ = GetDigits(a.currencyCode)
+		digits = getDigits(a.currencyCode)
 	}
 
+	return a.roundTo(digits, mode)
+}
+
+// WithDigits returns a with exactly the given number of fraction digits.
+//
+// Extra digits are rounded away using the given rounding mode, while missing
+// digits are added as trailing zeros (e.g. "10" becomes "10.00" for 2 digits),
+// and are reflected in Number() and String(). This is the same operation as
+// RoundTo, named for when the goal is fixing the scale rather than rounding.
+// Passing currency.DefaultDigits uses the currency's number of fraction digits.
+func (a Amount) WithDigits(digits uint8, mode RoundingMode) Amount {
+	return a.RoundTo(digits, mode)
+}
+
+// RoundToCurrencyDigits rounds a to the currency's number of fraction digits.
+//
+// Equivalent to RoundTo(currency.DefaultDigits, mode), without relying on the sentinel.
+func (a Amount) RoundToCurrencyDigits(mode RoundingMode) Amount {
+	digits := getDigits(a.currencyCode)
+
+	return a.roundTo(digits, mode)
+}
+
+// roundTo rounds a to the given number of fraction digits.
+func (a Amount) roundTo(digits uint8, mode RoundingMode) Amount {
 	result := apd.Decimal{}
 	ctx := roundingContext(&a.number, mode)
+	ctx = exactContext(ctx, quantizeDigits(&a.number, -int32(digits)))
 	ctx.Quantize(&result, &a.number, -int32(digits))
 
 	return Amount{result, a.currencyCode}
 }
 
+// QuantizeTo rounds a to the same number of fraction digits as reference.
+//
+// For example, "12.345" quantized to "1.50" becomes "12.35" (RoundHalfUp).
+func (a Amount) QuantizeTo(reference Amount, mode RoundingMode) (Amount, error) {
+	if a.currencyCode != reference.currencyCode {
+		return Amount{}, MismatchError{a, reference}
+	}
+	result := apd.Decimal{}
+	ctx := roundingContext(&a.number, mode)
+	ctx = exactContext(ctx, quantizeDigits(&a.number, reference.number.Exponent))
+	ctx.Quantize(&result, &a.number, reference.number.Exponent)
+
+	return Amount{result, a.currencyCode}, nil
+}
+
+// IntegerPart returns the integer part of a, with the same number of fraction digits.
+//
+// For example, the integer part of "12.99 USD" is "12.00 USD".
+// Useful for displays which show the major and minor units separately.
+func (a Amount) IntegerPart() Amount {
+	integer, _ := a.modf()
+
+	return integer
+}
+
+// FractionPart returns the fractional part of a.
+//
+// For example, the fractional part of "12.99 USD" is "0.99 USD".
+// The fractional part has the same sign as a, so that a is always equal
+// to IntegerPart() + FractionPart() (e.g. "-12.99 USD" gives "-0.99 USD").
+func (a Amount) FractionPart() Amount {
+	_, fraction := a.modf()
+
+	return fraction
+}
+
+// modf splits a into its integer and fractional parts.
+func (a Amount) modf() (integer, fraction Amount) {
+	integer.currencyCode = a.currencyCode
+	fraction.currencyCode = a.currencyCode
+	a.number.Modf(&integer.number, &fraction.number)
+	if a.number.Exponent < 0 {
+		// Keep the original number of fraction digits ("12.00", not "12").
+		ctx := exactContext(decimalContext(&integer.number), quantizeDigits(&integer.number, a.number.Exponent))
+		ctx.Quantize(&integer.number, &integer.number, a.number.Exponent)
+	}
+	// Avoid negative zeroes ("-0.00").
+	if integer.number.IsZero() {
+		integer.number.Negative = false
+	}
+	if fraction.number.IsZero() {
+		fraction.number.Negative = false
+	}
+
+	return integer, fraction
+}
+
+// RoundToDenomination rounds a to a multiple of the smallest denomination.
+//
+// For example, rounding to "0.05" is used for cash payments in
+// countries without 1 cent coins. Negative amounts are rounded
+// symmetrically, so RoundUp always rounds away from 0.
+func (a Amount) RoundToDenomination(smallest Amount, mode RoundingMode) (Amount, error) {
+	if a.currencyCode != smallest.currencyCode {
+		return Amount{}, MismatchError{a, smallest}
+	}
+	if !smallest.IsPositive() {
+		return Amount{}, InvalidNumberError{smallest.Number()}
+	}
+	result := apd.Decimal{}
+	if err := quoRounded(&result, &a.number, &smallest.number, 0, mode); err != nil {
+		return Amount{}, ArithmeticError{"RoundToDenomination", err}
+	}
+	ctx := decimalContext(&result, &smallest.number)
+	ctx = exactContext(ctx, mulDigits(&result, &smallest.number))
+	if _, err := ctx.Mul(&result, &result, &smallest.number); err != nil {
+		return Amount{}, ArithmeticError{"RoundToDenomination", err}
+	}
+
+	return Amount{result, a.currencyCode}, nil
+}
+
 // Cmp compares a and b and returns:
 //
 //	-1 if a <  b
@@ -253,7 +1040,78 @@ func (a Amount) Cmp(b Amount) (int, error) {
 	return a.number.Cmp(&b.number), nil
 }
 
+// CmpString compares a and the amount in s, and returns the same values as Cmp.
+//
+// The string s is expected in the String() form ("100.00 USD"),
+// or as a plain number ("100.00") in a's currency.
+// Handy for thresholds defined in configuration and tests.
+func (a Amount) CmpString(s string) (int, error) {
+	parts := strings.Fields(s)
+	if len(parts) == 0 || len(parts) > 2 {
+		return -1, InvalidNumberError{s}
+	}
+	number := apd.Decimal{}
+	if _, _, err := number.SetString(parts[0]); err != nil {
+		return -1, InvalidNumberError{parts[0]}
+	}
+	currencyCode := a.currencyCode
+	if len(parts) == 2 {
+		currencyCode = parts[1]
+		if currencyCode == "" || !IsValid(currencyCode) {
+			return -1, InvalidCurrencyCodeError{currencyCode}
+		}
+	}
+
+	return a.Cmp(Amount{number, currencyCode})
+}
+
+// CmpAbs compares the absolute values of a and b and returns:
+//
+//	-1 if |a| <  |b|
+//	0 if |a| == |b|
+//	+1 if |a| >  |b|
+func (a Amount) CmpAbs(b Amount) (int, error) {
+	if a.currencyCode != b.currencyCode {
+		return -1, MismatchError{a, b}
+	}
+	var aAbs, bAbs apd.Decimal
+	aAbs.Abs(&a.number)
+	bAbs.Abs(&b.number)
+
+	return aAbs.Cmp(&bAbs), nil
+}
+
+// InRange returns whether a is between low and high, inclusive.
+//
+// Returns MismatchError if low or high are in a different currency,
+// and ErrInvalidRange if low is greater than high.
+// See the example for validating amounts against per-currency ranges.
+func (a Amount) InRange(low, high Amount) (bool, error) {
+	c, err := low.Cmp(high)
+	if err != nil {
+		return false, err
+	}
+	if c == 1 {
+		return false, ErrInvalidRange
+	}
+	c, err = a.Cmp(low)
+	if err != nil {
+		return false, err
+	}
+	if c == -1 {
+		return false, nil
+	}
+	c, _ = a.Cmp(high)
+
+	return c != 1, nil
+}
+
 // Equal returns whether a and b are equal.
+//
+// Amounts are compared by value and currency code, ignoring the scale,
+// so "10.5 USD" is equal to "10.50 USD", but not to "10.50 EUR".
+// Prefer Equal over comparing String() or using the == operator,
+// which both take the scale into account.
 func (a Amount) Equal(b Amount) bool {
 	if a.currencyCode != b.currencyCode {
 		return false
@@ -261,6 +1119,104 @@ func (a Amount) Equal(b Amount) bool {
 	return a.number.Cmp(&b.number) == 0
 }
 
+// EqualAny returns whether a is equal to any of the given amounts.
+//
+// Amounts in a different currency are never equal, just like with Equal.
+func (a Amount) EqualAny(others ...Amount) bool {
+	for _, b := range others {
+		if a.Equal(b) {
+			return true
+		}
+	}
+	return false
+}
+
+// SameValue returns whether a and b have the same number, ignoring the currency.
+//
+// The scale is ignored as well, so "10.00 USD" has the same value as "10 EUR".
+// Meant for tests and analytics (e.g. checking a conversion at rate 1),
+// this is not monetary equality. Use Equal for that.
+func (a Amount) SameValue(b Amount) bool {
+	return a.number.Cmp(&b.number) == 0
+}
+
+// IsCompatible returns whether a and b can be added or subtracted.
+//
+// That is the case when they have the same currency code,
+// or when one of them is the zero value (Amount{}).
+// Amounts in different currencies must be converted first.
+func (a Amount) IsCompatible(b Amount) bool {
+	if a.currencyCode == b.currencyCode {
+		return true
+	}
+	return a.Equal(Amount{}) || b.Equal(Amount{})
+}
+
+// Coalesce returns the first amount that isn't the zero value (Amount{}).
+//
+// Returns the zero value if there is no such amount.
+// Note that a real zero, such as "0.00 USD", isn't the zero value.
+func Coalesce(amounts ...Amount) Amount {
+	for _, a := range amounts {
+		if a.currencyCode != "" {
+			return a
+		}
+	}
+	return Amount{}
+}
+
+// MaxAmount returns the largest of the given amounts.
+//
+// The zero value (Amount{}) is skipped. All other amounts must
+// share a currency code. Returns ErrNoAmounts if there
+// are no amounts other than the zero value.
+func MaxAmount(amounts ...Amount) (Amount, error) {
+	return extremeAmount(amounts, 1)
+}
+
+// MinAmount returns the smallest of the given amounts.
+//
+// The zero value (Amount{}) is skipped. All other amounts must
+// share a currency code. Returns ErrNoAmounts if there
+// are no amounts other than the zero value.
+func MinAmount(amounts ...Amount) (Amount, error) {
+	return extremeAmount(amounts, -1)
+}
+
+// extremeAmount returns the amount which compares as want (1 or -1) to all others.
+func extremeAmount(amounts []Amount, want int) (Amount, error) {
+	var result Amount
+	for _, a := range amounts {
+		if a.currencyCode == "" {
+			continue
+		}
+		if result.currencyCode == "" {
+			result = a
+			continue
+		}
+		c, err := a.Cmp(result)
+		if err != nil {
+			return Amount{}, err
+		}
+		if c == want {
+			result = a
+		}
+	}
+	if result.currencyCode == "" {
+		return Amount{}, ErrNoAmounts
+	}
+	return result, nil
+}
+
+// Sign returns:
+//
+//	-1 if a <  0
+//	0 if a == 0
+//	+1 if a >  0
+func (a Amount) Sign() int {
+	return a.number.Sign()
+}
+
 // IsPositive returns whether a is positive.
 func (a Amount) IsPositive() bool {
 	zero := apd.New(0, 0)
@@ -279,6 +1235,83 @@ func (a Amount) IsZero() bool {
 	return a.number.Cmp(zero) == 0
 }
 
+// IsSubunitPrecise returns whether a has no more fraction digits than its currency allows.
+//
+// Such amounts can be stored in minor units without rounding.
+// For example, "10.99 USD" and "10.990 USD" are subunit precise, "10.999 USD" is not.
+func (a Amount) IsSubunitPrecise() bool {
+	number := apd.Decimal{}
+	number.Reduce(&a.number)
+
+	return number.Exponent >= -int32(getDigits(a.currencyCode))
+}
+
+// IsMultipleOf returns whether a is an exact multiple of the given increment.
+//
+// For example, "0.75" is a multiple of "0.25", but "0.80" is not.
+// The increment must be a positive number.
+func (a Amount) IsMultipleOf(increment string) (bool, error) {
+	inc := apd.Decimal{}
+	if _, _, err := inc.SetString(increment); err != nil {
+		return false, InvalidNumberError{increment}
+	}
+	if inc.Sign() <= 0 {
+		return false, InvalidNumberError{increment}
+	}
+	result := apd.Decimal{}
+	ctx := decimalContext(&a.number, &inc)
+	ctx = exactContext(ctx, quoIntegerDigits(&a.number, &inc))
+	if _, err := ctx.Rem(&result, &a.number, &inc); err != nil {
+		return false, ArithmeticError{"IsMultipleOf", err}
+	}
+
+	return result.IsZero(), nil
+}
+
+// SortKey returns a byte encoding of a whose byte order matches the numeric order.
+//
+// The key starts with the currency code, so that the keys of each currency
+// form a contiguous range. It is followed by a sign byte, the exponent
+// (offset-binary, big-endian) and the significant digits, all inverted
+// for negative numbers. Numerically equal amounts (e.g. "1.5" and "1.50")
+// have equal keys. Allows range scans in key-value stores.
+func (a Amount) SortKey() []byte {
+	key := make([]byte, 0, len(a.currencyCode)+6+int(a.number.NumDigits()))
+	key = append(key, a.currencyCode...)
+	if a.IsZero() {
+		return append(key, 0x01)
+	}
+	negative := a.IsNegative()
+	if negative {
+		key = append(key, 0x00)
+	} else {
+		key = append(key, 0x02)
+	}
+	digits := a.number.Coeff.String()
+	trimmed := strings.TrimRight(digits, "0")
+	// The exponent of the most significant digit (e.g. 2 for "123.45").
+	exponent := int64(len(digits)) + int64(a.number.Exponent) - 1
+	e := uint32(int32(exponent)) ^ 0x80000000
+	if negative {
+		e = ^e
+	}
+	key = append(key, byte(e>>24), byte(e>>16), byte(e>>8), byte(e))
+	for i := 0; i < len(trimmed); i++ {
+		d := trimmed[i] - '0'
+		if negative {
+			key = append(key, 0xfe-d)
+		} else {
+			key = append(key, d+1)
+		}
+	}
+	if negative {
+		// Ensure that shorter digit sequences sort after longer ones.
+		key = append(key, 0xff)
+	}
+
+	return key
+}
+
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
 func (a Amount) MarshalBinary() ([]byte, error) {
 	buf := bytes.Buffer{}
@@ -309,7 +1342,16 @@ func (a *Amount) UnmarshalBinary(data []byte) error {
 }
 
 // MarshalJSON implements the json.Marshaler interface.
+//
+// The output is guaranteed to be {"number":"3.45","currency":"USD"},
+// with the keys in that order and no whitespace, making it suitable
+// for golden tests and signatures. The number is formatted as by Number,
+// preserving trailing zeros and never using scientific notation.
+// The zero value is marshaled as null, since it has no currency code.
 func (a Amount) MarshalJSON() ([]byte, error) {
+	if a.currencyCode == "" {
+		return []byte("null"), nil
+	}
 	return json.Marshal(&struct {
 		Number       string `json:"number"`
 		CurrencyCode string `json:"currency"`
@@ -320,7 +1362,13 @@ func (a Amount) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// A null value is unmarshaled as the zero value.
 func (a *Amount) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*a = Amount{}
+		return nil
+	}
 	aux := struct {
 		Number       json.RawMessage `json:"number"`
 		CurrencyCode string          `json:"currency"`
@@ -358,6 +1406,11 @@ func (a Amount) Value() (driver.Value, error) {
 // Scan implements the database/sql.Scanner interface.
 //
 // Allows scanning amounts from a PostgreSQL composite type.
+//
+// The number is scanned as-is, keeping the scale used by the database.
+// For example, "10.5" stored in a numeric(19,3) column is scanned as "10.500".
+// Such amounts are still Equal to the original, use Round() to
+// normalize the number to the currency's digits if needed.
 func (a *Amount) Scan(src interface{}) error {
 	// Wire format: "(9.99,USD)".
 	input, ok := src.(string)
@@ -409,21 +1462,100 @@ func decimalContext(decimals ...*apd.Decimal) *apd.Context {
 	return decimalContextPrecision19
 }
 
+// exactContext returns a decimal context with enough precision to
+// hold a result with the given number of digits without rounding.
+func exactContext(ctx *apd.Context, digits int64) *apd.Context {
+	if digits <= int64(ctx.Precision) {
+		return ctx
+	}
+	return ctx.WithPrecision(uint32(digits))
+}
+
+// addDigits returns the maximum number of digits in the sum of x and y.
+func addDigits(x, y *apd.Decimal) int64 {
+	xHigh := x.NumDigits() + int64(x.Exponent)
+	yHigh := y.NumDigits() + int64(y.Exponent)
+	high, low := xHigh, int64(x.Exponent)
+	if yHigh > high {
+		high = yHigh
+	}
+	if int64(y.Exponent) < low {
+		low = int64(y.Exponent)
+	}
+	// Allow an extra digit for the carry.
+	return high - low + 1
+}
+
+// mulDigits returns the maximum number of digits in the product of x and y.
+func mulDigits(x, y *apd.Decimal) int64 {
+	return x.NumDigits() + y.NumDigits()
+}
+
+// quoIntegerDigits returns the maximum number of digits in
+// the integer quotient of x and y, and in their remainder.
+func quoIntegerDigits(x, y *apd.Decimal) int64 {
+	low := x.Exponent
+	if y.Exponent < low {
+		low = y.Exponent
+	}
+	// Scaled to the same exponent, the quotient can't have more
+	// digits than x, and the remainder can't have more digits than y.
+	xDigits := x.NumDigits() + int64(x.Exponent-low)
+	yDigits := y.NumDigits() + int64(y.Exponent-low)
+	if yDigits > xDigits {
+		return yDigits
+	}
+	return xDigits
+}
+
+// quantizeDigits returns the maximum number of digits in d quantized to the given exponent.
+func quantizeDigits(d *apd.Decimal, exponent int32) int64 {
+	high := d.NumDigits() + int64(d.Exponent)
+	if high < 1 {
+		high = 1
+	}
+	// Allow an extra digit for rounding up (e.g. 99.995 => 100.00).
+	return high - int64(exponent) + 1
+}
+
+// rounders maps rounding modes to their apd equivalents.
+var rounders = [...]apd.Rounder{
+	RoundHalfUp:   apd.RoundHalfUp,
+	RoundHalfDown: apd.RoundHalfDown,
+	RoundUp:       apd.RoundUp,
+	RoundDown:     apd.RoundDown,
+	RoundHalfEven: apd.RoundHalfEven,
+}
+
+var (
+	roundingContextsPrecision19 = newRoundingContexts(decimalContextPrecision19)
+	roundingContextsPrecision39 = newRoundingContexts(decimalContextPrecision39)
+)
+
+// newRoundingContexts returns a copy of the given context for each rounding mode.
+func newRoundingContexts(ctx *apd.Context) []*apd.Context {
+	contexts := make([]*apd.Context, len(rounders))
+	for mode, rounder := range rounders {
+		modeCtx := *ctx
+		modeCtx.Rounding = rounder
+		contexts[mode] = &modeCtx
+	}
+
+	return contexts
+}
+
 // roundingContext returns the decimal context to use for rounding.
-// It optimizes for the most common RoundHalfUp mode by returning a preallocated global context for it.
+// The contexts are preallocated for each rounding mode, avoiding allocations.
+// Just like with decimalContext, the returned context must not be modified.
 func roundingContext(decimal *apd.Decimal, mode RoundingMode) *apd.Context {
-	if mode == RoundHalfUp {
-		return decimalContext(decimal)
+	if int(mode) >= len(rounders) {
+		ctx := *decimalContext(decimal)
+		ctx.Rounding = ""
+		return &ctx
 	}
-
-	extModes := map[RoundingMode]apd.Rounder{
-		RoundHalfDown: apd.RoundHalfDown,
-		RoundUp:       apd.RoundUp,
-		RoundDown:     apd.RoundDown,
-		RoundHalfEven: apd.RoundHalfEven,
+	if decimalContext(decimal) == decimalContextPrecision39 {
+		return roundingContextsPrecision39[mode]
 	}
-	ctx := *decimalContext(decimal)
-	ctx.Rounding = extModes[mode]
 
-	return &ctx
+	return roundingContextsPrecision19[mode]
 }
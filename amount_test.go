@@ -4,9 +4,12 @@
 package currency_test
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
+	"reflect"
 	"sync"
 	"testing"
 
@@ -53,6 +56,38 @@ func TestNewAmount(t *testing.T) {
 	if a.String() != "10.99 USD" {
 		t.Errorf("got %v, want 10.99 USD", a.String())
 	}
+	if a.StringCodeFirst() != "USD 10.99" {
+		t.Errorf("got %v, want USD 10.99", a.StringCodeFirst())
+	}
+
+	// Very small numbers are not shown in scientific notation.
+	a, _ = currency.NewAmount("0.00000001", "USD")
+	if a.Number() != "0.00000001" {
+		t.Errorf("got %v, want 0.00000001", a.Number())
+	}
+
+	// Neither are numbers with a positive exponent.
+	for _, n := range []string{"1E+3", "1e3", "1000"} {
+		a, _ = currency.NewAmount(n, "USD")
+		if a.Number() != "1000" {
+			t.Errorf("got %v, want 1000", a.Number())
+		}
+		if a.String() != "1000 USD" {
+			t.Errorf("got %v, want 1000 USD", a.String())
+		}
+		d, _ := json.Marshal(a)
+		if string(d) != `{"number":"1000","currency":"USD"}` {
+			t.Errorf("got %s, want {\"number\":\"1000\",\"currency\":\"USD\"}", d)
+		}
+		v, _ := a.Value()
+		if v != "(1000,USD)" {
+			t.Errorf("got %v, want (1000,USD)", v)
+		}
+	}
+	a, _ = currency.NewAmount("1.5E+3", "USD")
+	if a.Number() != "1500" {
+		t.Errorf("got %v, want 1500", a.Number())
+	}
 }
 
 func TestNewAmountFromBigInt(t *testing.T) {
@@ -134,6 +169,8 @@ func TestNewAmountFromInt64(t *testing.T) {
 		{2099, "USD", "20.99"},
 		{5000, "USD", "50.00"},
 		{50, "JPY", "50"},
+		{50, "CLF", "0.0050"},
+		{-123456789, "CLF", "-12345.6789"},
 	}
 
 	for _, tt := range tests {
@@ -152,6 +189,265 @@ func TestNewAmountFromInt64(t *testing.T) {
 	}
 }
 
+func TestNewAmountFromMinorUnits(t *testing.T) {
+	_, err := currency.NewAmountFromMinorUnits(1099, 2, "usd")
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "usd" {
+			t.Errorf("got %v, want usd", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+
+	tests := []struct {
+		units        int64
+		scale        uint8
+		currencyCode string
+		wantNumber   string
+	}{
+		{2099, 0, "USD", "2099"},
+		{2099, 2, "USD", "20.99"},
+		{209900, 4, "USD", "20.9900"},
+		{123456, 4, "USD", "12.3456"},
+		{-123456, 4, "USD", "-12.3456"},
+		{50, 2, "JPY", "0.50"},
+		{50, 0, "KWD", "50"},
+		{0, 4, "USD", "0.0000"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, err := currency.NewAmountFromMinorUnits(tt.units, tt.scale, tt.currencyCode)
+			if err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+			if a.Number() != tt.wantNumber {
+				t.Errorf("got %v, want %v", a.Number(), tt.wantNumber)
+			}
+			if a.CurrencyCode() != tt.currencyCode {
+				t.Errorf("got %v, want %v", a.CurrencyCode(), tt.currencyCode)
+			}
+		})
+	}
+}
+
+func TestNewAmountForLocale(t *testing.T) {
+	_, err := currency.NewAmountForLocale("INVALID", currency.NewLocale("de-CH"))
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "INVALID" {
+			t.Errorf("got %v, want INVALID", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	for _, localeID := range []string{"", "de", "en-001", "en-ZZ"} {
+		_, err = currency.NewAmountForLocale("10.99", currency.NewLocale(localeID))
+		if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+			if e.CurrencyCode != "" {
+				t.Errorf("%v: got %v, want empty currency code", localeID, e.CurrencyCode)
+			}
+		} else {
+			t.Errorf("%v: got %T, want currency.InvalidCurrencyCodeError", localeID, err)
+		}
+	}
+
+	tests := []struct {
+		localeID string
+		want     string
+	}{
+		{"en-US", "10.99 USD"},
+		{"de-CH", "10.99 CHF"},
+		{"fr-CH", "10.99 CHF"},
+		{"sr-Latn-RS", "10.99 RSD"},
+		{"ja_jp", "10.99 JPY"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.localeID, func(t *testing.T) {
+			a, err := currency.NewAmountForLocale("10.99", currency.NewLocale(tt.localeID))
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if a.String() != tt.want {
+				t.Errorf("got %v, want %v", a.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAmountPermissive(t *testing.T) {
+	for _, currencyCode := range []string{"", "usd", "US", "USDT", "U$D"} {
+		_, err := currency.NewAmountPermissive("10.99", currencyCode)
+		if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+			if e.CurrencyCode != currencyCode {
+				t.Errorf("got %v, want %v", e.CurrencyCode, currencyCode)
+			}
+		} else {
+			t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+		}
+	}
+	_, err := currency.NewAmountPermissive("INVALID", "USD")
+	if _, ok := err.(currency.InvalidNumberError); !ok {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	a, err := currency.NewAmountPermissive("10.999", "KWD")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if a.Round().String() != "10.999 KWD" {
+		t.Errorf("got %v, want 10.999 KWD", a.Round().String())
+	}
+
+	// Unknown currencies are assumed to have 2 fraction digits.
+	b, err := currency.NewAmountPermissive("10.999", "ZZZ")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if b.String() != "10.999 ZZZ" {
+		t.Errorf("got %v, want 10.999 ZZZ", b.String())
+	}
+	if b.Round().String() != "11.00 ZZZ" {
+		t.Errorf("got %v, want 11.00 ZZZ", b.Round().String())
+	}
+	// The currency code is used as the symbol.
+	formatter := currency.NewFormatter(currency.NewLocale("en"))
+	if got := formatter.Format(b.Round()); got != "ZZZ\u00a011.00" {
+		t.Errorf("got %v, want ZZZ\u00a011.00", got)
+	}
+}
+
+func TestMustNewAmount(t *testing.T) {
+	a := currency.MustNewAmount("10.99", "USD")
+	if a.String() != "10.99 USD" {
+		t.Errorf("got %v, want 10.99 USD", a.String())
+	}
+	a = currency.MustNewAmountFromBigInt(big.NewInt(1099), "USD")
+	if a.String() != "10.99 USD" {
+		t.Errorf("got %v, want 10.99 USD", a.String())
+	}
+	a = currency.MustNewAmountFromInt64(1099, "USD")
+	if a.String() != "10.99 USD" {
+		t.Errorf("got %v, want 10.99 USD", a.String())
+	}
+
+	tests := []struct {
+		name      string
+		fn        func()
+		wantError string
+	}{
+		{"MustNewAmount", func() { currency.MustNewAmount("INVALID", "USD") }, `invalid number "INVALID"`},
+		{"MustNewAmountFromBigInt", func() { currency.MustNewAmountFromBigInt(nil, "USD") }, `invalid number "nil"`},
+		{"MustNewAmountFromInt64", func() { currency.MustNewAmountFromInt64(1099, "usd") }, `invalid currency code "usd"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				err, ok := r.(error)
+				if !ok {
+					t.Fatalf("got %v, want a panic with an error", r)
+				}
+				if err.Error() != tt.wantError {
+					t.Errorf("got %v, want %v", err.Error(), tt.wantError)
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
+
+func TestAmount_Copy(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+	}{
+		{"10.50", "USD"},
+		{"-3.333", "EUR"},
+		{"123456789012345678901234567890.123456789", "USD"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			b := a.Copy()
+			if b.Number() != tt.number {
+				t.Errorf("got %v, want %v", b.Number(), tt.number)
+			}
+			if b.CurrencyCode() != tt.currencyCode {
+				t.Errorf("got %v, want %v", b.CurrencyCode(), tt.currencyCode)
+			}
+			// Operating on the copy must not affect the original.
+			b, _ = b.Add(b)
+			if a.Number() != tt.number {
+				t.Errorf("original modified: got %v, want %v", a.Number(), tt.number)
+			}
+		})
+	}
+
+	var z currency.Amount
+	got := z.Copy()
+	if !got.Equal(z) {
+		t.Errorf("got %v, want the zero value", got)
+	}
+}
+
+func TestAmount_StringGrouped(t *testing.T) {
+	tests := []struct {
+		number string
+		want   string
+	}{
+		{"0", "0 USD"},
+		{"999.99", "999.99 USD"},
+		{"1000", "1,000 USD"},
+		{"1234567.99", "1,234,567.99 USD"},
+		{"-1234567.99", "-1,234,567.99 USD"},
+		{"-123.45", "-123.45 USD"},
+		{"123456", "123,456 USD"},
+		{"0.000000012", "0.000000012 USD"},
+		{"922337203685477598799", "922,337,203,685,477,598,799 USD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			got := a.StringGrouped()
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			// Confirm that String is unchanged.
+			if a.String() != tt.number+" USD" {
+				t.Errorf("got %v, want %v", a.String(), tt.number+" USD")
+			}
+		})
+	}
+}
+
+func TestAmount_Text(t *testing.T) {
+	tests := []struct {
+		number string
+		format byte
+		want   string
+	}{
+		{"0.000000012", 'f', "0.000000012 USD"},
+		{"0.000000012", 'e', "1.2e-8 USD"},
+		{"0.000000012", 'E', "1.2E-8 USD"},
+		{"0.000000012", 'g', "1.2e-8 USD"},
+		{"-1234.560", 'f', "-1234.560 USD"},
+		{"-1234.560", 'e', "-1.234560e+3 USD"},
+		{"-1234.560", 'G', "-1234.560 USD"},
+		{"1E+3", 'f', "1000 USD"},
+		{"1E+3", 'g', "1e+3 USD"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			got := a.Text(tt.format)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestAmount_BigInt(t *testing.T) {
 	tests := []struct {
 		number       string
@@ -206,6 +502,8 @@ func TestAmount_Int64(t *testing.T) {
 		// Number with no decimals.
 		{"50", "USD", 5000},
 		{"50", "JPY", 50},
+		{"50", "CLF", 500000},
+		{"12.34565", "CLF", 123457},
 	}
 
 	for _, tt := range tests {
@@ -223,6 +521,40 @@ func TestAmount_Int64(t *testing.T) {
 	}
 }
 
+func TestAmount_Float64(t *testing.T) {
+	tests := []struct {
+		number    string
+		want      float64
+		wantExact bool
+	}{
+		{"0", 0, true},
+		{"20.00", 20, true},
+		{"-20", -20, true},
+		{"20.5", 20.5, true},
+		{"20.25", 20.25, true},
+		{"0.125", 0.125, true},
+
+		{"20.99", 20.99, false},
+		{"0.1", 0.1, false},
+		{"-0.3", -0.3, false},
+		// An integer larger than 2^53.
+		{"9007199254740993", 9007199254740992, false},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			got, gotExact := a.Float64()
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if gotExact != tt.wantExact {
+				t.Errorf("exact: got %v, want %v", gotExact, tt.wantExact)
+			}
+		})
+	}
+}
+
 func TestAmount_Convert(t *testing.T) {
 	a, _ := currency.NewAmount("20.99", "USD")
 
@@ -275,6 +607,120 @@ func TestAmount_Convert(t *testing.T) {
 	}
 }
 
+func TestAmount_ConvertInverse(t *testing.T) {
+	a, _ := currency.NewAmount("20.99", "USD")
+
+	_, err := a.ConvertInverse("eur", "1.1")
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "eur" {
+			t.Errorf("got %v, want eur", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+
+	for _, n := range []string{"INVALID", "0"} {
+		_, err = a.ConvertInverse("EUR", n)
+		if e, ok := err.(currency.InvalidNumberError); ok {
+			if e.Number != n {
+				t.Errorf("got %v, want %v", e.Number, n)
+			}
+		} else {
+			t.Errorf("got %T, want currency.InvalidNumberError", err)
+		}
+	}
+
+	// An inverse rate of 0.8 is equivalent to a rate of 1.25.
+	b, err := a.ConvertInverse("EUR", "0.8")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	c, _ := a.Convert("EUR", "1.25")
+	if !b.Equal(c) {
+		t.Errorf("got %v, want %v", b, c)
+	}
+	if b.String() != "26.2375 EUR" {
+		t.Errorf("got %v, want 26.2375 EUR", b.String())
+	}
+	// Confirm that a is unchanged.
+	if a.String() != "20.99 USD" {
+		t.Errorf("got %v, want 20.99 USD", a.String())
+	}
+
+	// An inverse rate of 3 has no exact rate.
+	d, _ := currency.NewAmount("30", "USD")
+	e, err := d.ConvertInverse("EUR", "3")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if e.String() != "10 EUR" {
+		t.Errorf("got %v, want 10 EUR", e.String())
+	}
+	f, _ := d.Convert("EUR", "0.3333333333")
+	if f.Equal(e) {
+		t.Errorf("got %v, want a different amount than %v", f, e)
+	}
+}
+
+func TestAmount_ConvertChain(t *testing.T) {
+	a, _ := currency.NewAmount("20.99", "USD")
+
+	_, err := a.ConvertChain([]currency.ConversionStep{
+		{From: "USD", To: "EUR", Rate: "0.91"},
+		{From: "USD", To: "RSD", Rate: "117.17"},
+	})
+	if e, ok := err.(currency.StepMismatchError); ok {
+		if e.Expected != "EUR" {
+			t.Errorf("got %v, want EUR", e.Expected)
+		}
+		if e.Actual != "USD" {
+			t.Errorf("got %v, want USD", e.Actual)
+		}
+		want := `conversion step from "USD" doesn't match the current currency "EUR"`
+		if e.Error() != want {
+			t.Errorf("got %v, want %v", e.Error(), want)
+		}
+	} else {
+		t.Errorf("got %T, want currency.StepMismatchError", err)
+	}
+
+	_, err = a.ConvertChain([]currency.ConversionStep{
+		{From: "USD", To: "EUR", Rate: "0.91"},
+		{From: "EUR", To: "RSD", Rate: "INVALID"},
+	})
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "INVALID" {
+			t.Errorf("got %v, want INVALID", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	b, err := a.ConvertChain(nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if b != a {
+		t.Errorf("got %v, want %v", b, a)
+	}
+
+	// The intermediate results are not rounded.
+	c, err := a.ConvertChain([]currency.ConversionStep{
+		{From: "USD", To: "EUR", Rate: "0.91"},
+		{From: "EUR", To: "RSD", Rate: "117.17"},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if c.String() != "2238.052453 RSD" {
+		t.Errorf("got %v, want 2238.052453 RSD", c.String())
+	}
+	// Confirm that a is unchanged.
+	if a.String() != "20.99 USD" {
+		t.Errorf("got %v, want 20.99 USD", a.String())
+	}
+}
+
 func TestAmount_Add(t *testing.T) {
 	a, _ := currency.NewAmount("20.99", "USD")
 	b, _ := currency.NewAmount("3.50", "USD")
@@ -322,6 +768,16 @@ func TestAmount_Add(t *testing.T) {
 		t.Errorf("got %v, want 9223372036854775827.99 USD", e.String())
 	}
 
+	// Results beyond 39 digits are not rounded.
+	h, _ := currency.NewAmount("12345678901234567890123456789012345678901234567890", "USD")
+	i, err := h.Add(a)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if i.String() != "12345678901234567890123456789012345678901234567910.99 USD" {
+		t.Errorf("got %v, want 12345678901234567890123456789012345678901234567910.99 USD", i.String())
+	}
+
 	// Test that addition with the zero value works and yields the other operand.
 	f, err := a.Add(z)
 	if err != nil {
@@ -409,28 +865,142 @@ func TestAmount_Sub(t *testing.T) {
 	}
 }
 
-func TestAmount_Mul(t *testing.T) {
+func TestAmount_AddNumber(t *testing.T) {
 	a, _ := currency.NewAmount("20.99", "USD")
-
-	_, err := a.Mul("INVALID")
+	_, err := a.AddNumber("INVALID")
 	if e, ok := err.(currency.InvalidNumberError); ok {
 		if e.Number != "INVALID" {
 			t.Errorf("got %v, want INVALID", e.Number)
 		}
-		wantError := `invalid number "INVALID"`
-		if e.Error() != wantError {
-			t.Errorf("got %v, want %v", e.Error(), wantError)
-		}
 	} else {
 		t.Errorf("got %T, want currency.InvalidNumberError", err)
 	}
 
-	b, err := a.Mul("0.20")
-	if err != nil {
-		t.Errorf("unexpected error: %v", err)
+	tests := []struct {
+		number string
+		n      string
+		want   string
+	}{
+		{"20.99", "2.50", "23.49"},
+		{"20.99", "-2.50", "18.49"},
+		{"20.99", "0", "20.99"},
+		{"-20.99", "2.5", "-18.49"},
+		{"922337203685477598799", "0.01", "922337203685477598799.01"},
 	}
-	if b.String() != "4.1980 USD" {
-		t.Errorf("got %v, want 4.1980 USD", b.String())
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			b, err := a.AddNumber(tt.n)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if b.Number() != tt.want {
+				t.Errorf("got %v, want %v", b.Number(), tt.want)
+			}
+			if b.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", b.CurrencyCode())
+			}
+		})
+	}
+}
+
+func TestAmount_SubNumber(t *testing.T) {
+	a, _ := currency.NewAmount("20.99", "USD")
+	_, err := a.SubNumber("INVALID")
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "INVALID" {
+			t.Errorf("got %v, want INVALID", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	tests := []struct {
+		number string
+		n      string
+		want   string
+	}{
+		{"20.99", "2.50", "18.49"},
+		{"20.99", "-2.50", "23.49"},
+		{"20.99", "0", "20.99"},
+		{"2.50", "20.99", "-18.49"},
+		{"922337203685477598799", "0.01", "922337203685477598798.99"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			b, err := a.SubNumber(tt.n)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if b.Number() != tt.want {
+				t.Errorf("got %v, want %v", b.Number(), tt.want)
+			}
+			if b.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", b.CurrencyCode())
+			}
+		})
+	}
+}
+
+func TestAmount_NegateIf(t *testing.T) {
+	tests := []struct {
+		number string
+		cond   bool
+		want   string
+	}{
+		{"20.99", true, "-20.99"},
+		{"20.99", false, "20.99"},
+		{"-20.99", true, "20.99"},
+		{"-20.99", false, "-20.99"},
+		{"0.00", true, "0.00"},
+		{"-0.00", true, "0.00"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			b := a.NegateIf(tt.cond)
+			if b.Number() != tt.want {
+				t.Errorf("got %v, want %v", b.Number(), tt.want)
+			}
+			if b.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", b.CurrencyCode())
+			}
+			// Confirm that a is unchanged.
+			if a.Number() != tt.number {
+				t.Errorf("got %v, want %v", a.Number(), tt.number)
+			}
+		})
+	}
+
+	var z currency.Amount
+	if got := z.NegateIf(true); got != z {
+		t.Errorf("got %v, want the zero value", got)
+	}
+}
+
+func TestAmount_Mul(t *testing.T) {
+	a, _ := currency.NewAmount("20.99", "USD")
+
+	_, err := a.Mul("INVALID")
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "INVALID" {
+			t.Errorf("got %v, want INVALID", e.Number)
+		}
+		wantError := `invalid number "INVALID"`
+		if e.Error() != wantError {
+			t.Errorf("got %v, want %v", e.Error(), wantError)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	b, err := a.Mul("0.20")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if b.String() != "4.1980 USD" {
+		t.Errorf("got %v, want 4.1980 USD", b.String())
 	}
 	// Confirm that a is unchanged.
 	if a.String() != "20.99 USD" {
@@ -446,42 +1016,549 @@ func TestAmount_Mul(t *testing.T) {
 	if e.String() != "92233720368547758070 USD" {
 		t.Errorf("got %v, want 92233720368547758070 USD", e.String())
 	}
+
+	// Results beyond 39 digits are not rounded.
+	f, _ := currency.NewAmount("1234567890123456789012345.6789", "USD")
+	g, err := f.Mul("9876543210987654321098765.4321")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	want := "12193263113702179522618503273362292333223746380111.12635269 USD"
+	if g.String() != want {
+		t.Errorf("got %v, want %v", g.String(), want)
+	}
+}
+
+func TestAmount_MulRounded(t *testing.T) {
+	a, _ := currency.NewAmount("20.99", "USD")
+	_, err := a.MulRounded("INVALID", currency.RoundHalfUp)
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "INVALID" {
+			t.Errorf("got %v, want INVALID", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	tests := []struct {
+		number       string
+		currencyCode string
+		n            string
+		mode         currency.RoundingMode
+		want         string
+	}{
+		{"20.99", "USD", "0.20", currency.RoundHalfUp, "4.20"},
+		{"20.99", "USD", "0.20", currency.RoundDown, "4.19"},
+		{"-20.99", "USD", "0.20", currency.RoundHalfUp, "-4.20"},
+		{"20.99", "USD", "3", currency.RoundHalfUp, "62.97"},
+		{"1000", "JPY", "0.085", currency.RoundHalfUp, "85"},
+		{"1234", "JPY", "0.085", currency.RoundHalfEven, "105"},
+		{"10.000", "KWD", "0.0375", currency.RoundHalfUp, "0.375"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			b, err := a.MulRounded(tt.n, tt.mode)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if b.Number() != tt.want {
+				t.Errorf("got %v, want %v", b.Number(), tt.want)
+			}
+			if b.CurrencyCode() != tt.currencyCode {
+				t.Errorf("got %v, want %v", b.CurrencyCode(), tt.currencyCode)
+			}
+		})
+	}
+}
+
+func TestAmount_ApplyDiscount(t *testing.T) {
+	a, _ := currency.NewAmount("20.99", "USD")
+	negative, _ := currency.NewAmount("-20.99", "USD")
+	errorTests := []struct {
+		a           currency.Amount
+		percent     string
+		fixed       string
+		maxDiscount string
+		wantNumber  string
+	}{
+		{a, "INVALID", "0", "", "INVALID"},
+		{a, "-10", "0", "", "-10"},
+		{a, "10", "INVALID", "", "INVALID"},
+		{a, "10", "-5", "", "-5"},
+		{a, "10", "0", "INVALID", "INVALID"},
+		{a, "10", "0", "-5", "-5"},
+		{negative, "10", "0", "", "-20.99"},
+	}
+	for _, tt := range errorTests {
+		t.Run("", func(t *testing.T) {
+			_, _, err := tt.a.ApplyDiscount(tt.percent, tt.fixed, tt.maxDiscount, currency.RoundHalfUp)
+			if e, ok := err.(currency.InvalidNumberError); ok {
+				if e.Number != tt.wantNumber {
+					t.Errorf("got %v, want %v", e.Number, tt.wantNumber)
+				}
+			} else {
+				t.Errorf("got %T, want currency.InvalidNumberError", err)
+			}
+		})
+	}
+
+	tests := []struct {
+		number       string
+		currencyCode string
+		percent      string
+		fixed        string
+		maxDiscount  string
+		mode         currency.RoundingMode
+		wantFinal    string
+		wantDiscount string
+	}{
+		{"100.00", "USD", "10", "0", "", currency.RoundHalfUp, "90.00", "10.00"},
+		{"100.00", "USD", "10", "5", "", currency.RoundHalfUp, "85.00", "15.00"},
+		{"100", "USD", "0", "0", "", currency.RoundHalfUp, "100.00", "0.00"},
+		// The cap binds.
+		{"200.00", "USD", "20", "0", "25", currency.RoundHalfUp, "175.00", "25.00"},
+		{"200.00", "USD", "10", "10", "25", currency.RoundHalfUp, "175.00", "25.00"},
+		{"200.00", "USD", "10", "0", "25", currency.RoundHalfUp, "180.00", "20.00"},
+		// The floor binds.
+		{"10.00", "USD", "0", "15", "", currency.RoundHalfUp, "0.00", "10.00"},
+		{"10.00", "USD", "50", "8", "20", currency.RoundHalfUp, "0.00", "10.00"},
+		{"10.00", "USD", "150", "0", "", currency.RoundHalfUp, "0.00", "10.00"},
+		// Rounding.
+		{"19.99", "USD", "15", "0", "", currency.RoundHalfUp, "16.99", "3.00"},
+		{"19.99", "USD", "15", "0", "", currency.RoundDown, "17.00", "2.99"},
+		{"19.995", "USD", "0", "1", "", currency.RoundHalfUp, "19.00", "1.00"},
+		{"1999", "JPY", "15", "0", "", currency.RoundHalfUp, "1699", "300"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			final, discount, err := a.ApplyDiscount(tt.percent, tt.fixed, tt.maxDiscount, tt.mode)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if final.Number() != tt.wantFinal {
+				t.Errorf("got final %v, want %v", final.Number(), tt.wantFinal)
+			}
+			if discount.Number() != tt.wantDiscount {
+				t.Errorf("got discount %v, want %v", discount.Number(), tt.wantDiscount)
+			}
+			if final.CurrencyCode() != tt.currencyCode || discount.CurrencyCode() != tt.currencyCode {
+				t.Errorf("got %v and %v, want %v", final.CurrencyCode(), discount.CurrencyCode(), tt.currencyCode)
+			}
+		})
+	}
+}
+
+func TestAmount_Div(t *testing.T) {
+	a, _ := currency.NewAmount("99.99", "USD")
+
+	for _, n := range []string{"INVALID", "0"} {
+		_, err := a.Div(n)
+		if e, ok := err.(currency.InvalidNumberError); ok {
+			if e.Number != n {
+				t.Errorf("got %v, want %v", e.Number, n)
+			}
+		} else {
+			t.Errorf("got %T, want currency.InvalidNumberError", err)
+		}
+	}
+
+	b, err := a.Div("3")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if b.String() != "33.33 USD" {
+		t.Errorf("got %v, want 33.33 USD", b.String())
+	}
+	// Confirm that a is unchanged.
+	if a.String() != "99.99 USD" {
+		t.Errorf("got %v, want 99.99 USD", a.String())
+	}
+
+	// An amount equal to math.MaxInt64.
+	d, _ := currency.NewAmount("9223372036854775807", "USD")
+	e, err := d.Div("0.5")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if e.String() != "18446744073709551614 USD" {
+		t.Errorf("got %v, want 18446744073709551614 USD", e.String())
+	}
+}
+
+func TestAmount_DivTo(t *testing.T) {
+	a, _ := currency.NewAmount("100", "USD")
+
+	for _, n := range []string{"INVALID", "0"} {
+		_, err := a.DivTo(n, 2, currency.RoundHalfUp)
+		if e, ok := err.(currency.InvalidNumberError); ok {
+			if e.Number != n {
+				t.Errorf("got %v, want %v", e.Number, n)
+			}
+		} else {
+			t.Errorf("got %T, want currency.InvalidNumberError", err)
+		}
+	}
+
+	tests := []struct {
+		number string
+		n      string
+		digits uint8
+		mode   currency.RoundingMode
+		want   string
+	}{
+		{"100", "3", 2, currency.RoundHalfUp, "33.33"},
+		{"100", "3", 4, currency.RoundHalfUp, "33.3333"},
+		{"100", "3", currency.DefaultDigits, currency.RoundHalfUp, "33.33"},
+		{"200", "3", currency.DefaultDigits, currency.RoundDown, "66.66"},
+		{"100", "3", 2, currency.RoundUp, "33.34"},
+		{"-100", "3", 2, currency.RoundUp, "-33.34"},
+		{"200", "3", 2, currency.RoundHalfUp, "66.67"},
+		{"200", "3", 0, currency.RoundDown, "66"},
+		{"100", "4", 2, currency.RoundHalfUp, "25.00"},
+		{"1", "8", 2, currency.RoundHalfUp, "0.13"},
+		{"1", "8", 2, currency.RoundHalfDown, "0.12"},
+		{"1", "8", 2, currency.RoundHalfEven, "0.12"},
+		// Just above a tie, past the truncated digits.
+		{"1.2500001", "10", 2, currency.RoundHalfDown, "0.13"},
+		{"0.001", "3", 2, currency.RoundUp, "0.01"},
+		{"0.001", "3", 2, currency.RoundHalfUp, "0.00"},
+		{"-0.001", "3", 2, currency.RoundUp, "-0.01"},
+		{"0", "3", 2, currency.RoundHalfUp, "0.00"},
+		{"12345678901234567890123456789012345678901234567890", "3", 2, currency.RoundHalfUp, "4115226300411522630041152263004115226300411522630.00"},
+		// Rounding carries into a new integer digit.
+		{"99999999999999999999999999999999999999.999", "1", 2, currency.RoundHalfUp, "100000000000000000000000000000000000000.00"},
+		{"-99999999999999999999999999999999999999.999", "1", 2, currency.RoundHalfUp, "-100000000000000000000000000000000000000.00"},
+		{"9.999", "1", 2, currency.RoundUp, "10.00"},
+		{"999999999999999999.999", "0.1", 0, currency.RoundHalfUp, "10000000000000000000"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			b, err := a.DivTo(tt.n, tt.digits, tt.mode)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if b.Number() != tt.want {
+				t.Errorf("got %v, want %v", b.Number(), tt.want)
+			}
+			if b.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", b.CurrencyCode())
+			}
+		})
+	}
+}
+
+func TestAmount_PercentChange(t *testing.T) {
+	a, _ := currency.NewAmount("100.00", "USD")
+	x, _ := currency.NewAmount("99.99", "EUR")
+	z, _ := currency.NewAmount("0", "USD")
+
+	_, err := a.PercentChange(x)
+	if _, ok := err.(currency.MismatchError); !ok {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
+	_, err = z.PercentChange(a)
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "0" {
+			t.Errorf("got %v, want 0", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	tests := []struct {
+		a    string
+		b    string
+		want string
+	}{
+		{"100.00", "125.00", "25"},
+		{"100.00", "75.00", "-25"},
+		{"100.00", "100.00", "0"},
+		{"100.00", "0", "-100"},
+		{"-50", "-25", "-50"},
+		{"-50", "50", "-200"},
+		{"3", "4", "33.33333333333333333"},
+		{"0.05", "0.10", "100"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.a, "USD")
+			b, _ := currency.NewAmount(tt.b, "USD")
+			got, err := a.PercentChange(b)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_Denominate(t *testing.T) {
+	a, _ := currency.NewAmount("10", "USD")
+	b, _ := currency.NewAmount("5", "EUR")
+	_, _, err := a.Denominate([]currency.Amount{b})
+	if e, ok := err.(currency.MismatchError); ok {
+		if e.A != a {
+			t.Errorf("got %v, want %v", e.A, a)
+		}
+		if e.B != b {
+			t.Errorf("got %v, want %v", e.B, b)
+		}
+	} else {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
+
+	b, _ = currency.NewAmount("0", "USD")
+	_, _, err = a.Denominate([]currency.Amount{b})
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "0" {
+			t.Errorf("got %v, want 0", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	a, _ = currency.NewAmount("-10", "USD")
+	b, _ = currency.NewAmount("5", "USD")
+	_, _, err = a.Denominate([]currency.Amount{b})
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "-10" {
+			t.Errorf("got %v, want -10", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	// The count doesn't fit into an int.
+	a, _ = currency.NewAmount("1000000000", "USD")
+	b, _ = currency.NewAmount("0.0000000001", "USD")
+	_, _, err = a.Denominate([]currency.Amount{b})
+	if e, ok := err.(currency.ArithmeticError); ok {
+		if e.Op != "Denominate" {
+			t.Errorf("got %v, want Denominate", e.Op)
+		}
+	} else {
+		t.Errorf("got %T, want currency.ArithmeticError", err)
+	}
+
+	tests := []struct {
+		number        string
+		denominations []string
+		want          map[string]int
+		wantRemainder string
+	}{
+		{"0", []string{"1", "5"}, map[string]int{}, "0"},
+		{"37.85", []string{"0.05", "0.10", "0.25", "1", "5", "10", "20"}, map[string]int{"20": 1, "10": 1, "5": 1, "1": 2, "0.25": 3, "0.10": 1}, "0.00"},
+		{"37.87", []string{"20", "10", "5", "1", "0.25", "0.10", "0.05"}, map[string]int{"20": 1, "10": 1, "5": 1, "1": 2, "0.25": 3, "0.10": 1}, "0.02"},
+		{"3", []string{"5", "10"}, map[string]int{}, "3"},
+		{"100", []string{"50"}, map[string]int{"50": 2}, "0"},
+		// More count digits than the default precision.
+		{"100000000", []string{"0.0000000001"}, map[string]int{"0.0000000001": 1000000000000000000}, "0.0000000000"},
+		{"123456789012345678901234567890123456789012345.67", []string{"100000000000000000000000000000000000000000000", "10000000000000000000000000000000000000000000"}, map[string]int{"100000000000000000000000000000000000000000000": 1, "10000000000000000000000000000000000000000000": 2}, "3456789012345678901234567890123456789012345.67"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			denominations := make([]currency.Amount, 0, len(tt.denominations))
+			for _, n := range tt.denominations {
+				d, _ := currency.NewAmount(n, "USD")
+				denominations = append(denominations, d)
+			}
+			got, remainder, err := a.Denominate(denominations)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if remainder.Number() != tt.wantRemainder {
+				t.Errorf("got %v, want %v", remainder.Number(), tt.wantRemainder)
+			}
+			if remainder.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", remainder.CurrencyCode())
+			}
+		})
+	}
+}
+
+func TestAllocateProportional(t *testing.T) {
+	total, _ := currency.NewAmount("10.00", "USD")
+	w, _ := currency.NewAmount("1.00", "USD")
+	x, _ := currency.NewAmount("1.00", "EUR")
+	zero, _ := currency.NewAmount("0", "USD")
+	negative, _ := currency.NewAmount("-1.00", "USD")
+	subMinor, _ := currency.NewAmount("10.005", "USD")
+
+	_, err := currency.AllocateProportional(total, []currency.Amount{w, x})
+	if _, ok := err.(currency.MismatchError); !ok {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
+	for _, weights := range [][]currency.Amount{{w, negative}, {zero, zero}, {}} {
+		_, err = currency.AllocateProportional(total, weights)
+		if _, ok := err.(currency.InvalidNumberError); !ok {
+			t.Errorf("got %T, want currency.InvalidNumberError", err)
+		}
+	}
+	_, err = currency.AllocateProportional(subMinor, []currency.Amount{w})
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "10.005" {
+			t.Errorf("got %v, want 10.005", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	tests := []struct {
+		total        string
+		currencyCode string
+		weights      []string
+		want         []string
+	}{
+		{"10.00", "USD", []string{"1", "1", "1"}, []string{"3.34", "3.33", "3.33"}},
+		{"-10.00", "USD", []string{"1", "1", "1"}, []string{"-3.34", "-3.33", "-3.33"}},
+		{"10.00", "USD", []string{"0", "1", "0", "1"}, []string{"0.00", "5.00", "0.00", "5.00"}},
+		{"-0.01", "USD", []string{"0", "1", "1"}, []string{"0.00", "-0.01", "0.00"}},
+		// Tax split across line items proportional to their pre-tax amounts.
+		{"8.25", "USD", []string{"19.99", "5.49", "74.52"}, []string{"1.65", "0.45", "6.15"}},
+		{"100", "USD", []string{"0.5", "0.25", "0.25"}, []string{"50.00", "25.00", "25.00"}},
+		{"0.05", "USD", []string{"1", "2", "3", "4"}, []string{"0.01", "0.01", "0.01", "0.02"}},
+		{"100", "JPY", []string{"1", "1", "1"}, []string{"34", "33", "33"}},
+		{"1.000", "KWD", []string{"1", "2"}, []string{"0.333", "0.667"}},
+		{"0", "USD", []string{"1", "2"}, []string{"0.00", "0.00"}},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			total, _ := currency.NewAmount(tt.total, tt.currencyCode)
+			var weights []currency.Amount
+			for _, n := range tt.weights {
+				weight, _ := currency.NewAmount(n, tt.currencyCode)
+				weights = append(weights, weight)
+			}
+			parts, err := currency.AllocateProportional(total, weights)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			var got []string
+			sum := currency.Amount{}
+			for _, part := range parts {
+				got = append(got, part.Number())
+				sum, _ = sum.Add(part)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if cmp, _ := sum.Cmp(total); cmp != 0 {
+				t.Errorf("got sum %v, want %v", sum, total)
+			}
+		})
+	}
 }
 
-func TestAmount_Div(t *testing.T) {
-	a, _ := currency.NewAmount("99.99", "USD")
+func TestRoundPreservingSum(t *testing.T) {
+	target, _ := currency.NewAmount("10.00", "USD")
+	a, _ := currency.NewAmount("3.333", "USD")
+	x, _ := currency.NewAmount("3.333", "EUR")
 
-	for _, n := range []string{"INVALID", "0"} {
-		_, err := a.Div(n)
-		if e, ok := err.(currency.InvalidNumberError); ok {
-			if e.Number != n {
-				t.Errorf("got %v, want %v", e.Number, n)
-			}
-		} else {
-			t.Errorf("got %T, want currency.InvalidNumberError", err)
-		}
+	_, err := currency.RoundPreservingSum([]currency.Amount{a, x}, target)
+	if _, ok := err.(currency.MismatchError); !ok {
+		t.Errorf("got %T, want currency.MismatchError", err)
 	}
 
-	b, err := a.Div("3")
-	if err != nil {
-		t.Errorf("unexpected error: %v", err)
-	}
-	if b.String() != "33.33 USD" {
-		t.Errorf("got %v, want 33.33 USD", b.String())
+	tests := []struct {
+		numbers   []string
+		target    string
+		want      []string
+		wantError bool
+	}{
+		{[]string{"3.333", "3.333", "3.334"}, "10.00", []string{"3.33", "3.33", "3.34"}, false},
+		{[]string{"3.3333", "3.3333", "3.3334"}, "10.00", []string{"3.33", "3.33", "3.34"}, false},
+		{[]string{"1.005", "1.005", "1.005"}, "3.02", []string{"1.01", "1.01", "1.00"}, false},
+		{[]string{"1.005", "1.005", "1.005"}, "3.00", []string{"1.00", "1.00", "1.00"}, false},
+		{[]string{"1.005", "1.005", "1.005"}, "3.03", []string{"1.01", "1.01", "1.01"}, false},
+		{[]string{"-3.333", "-3.333", "-3.334"}, "-10.00", []string{"-3.33", "-3.33", "-3.34"}, false},
+		{[]string{"5.50", "4.50"}, "10.00", []string{"5.50", "4.50"}, false},
+		{[]string{"0.004", "0.004", "0.002"}, "0.01", []string{"0.01", "0.00", "0.00"}, false},
+		{nil, "0", []string{}, false},
+		// Unreachable targets.
+		{[]string{"1.005", "1.005", "1.005"}, "2.99", nil, true},
+		{[]string{"1.005", "1.005", "1.005"}, "3.04", nil, true},
+		{nil, "1.00", nil, true},
+		// Too many fraction digits.
+		{[]string{"1.005"}, "1.005", nil, true},
 	}
-	// Confirm that a is unchanged.
-	if a.String() != "99.99 USD" {
-		t.Errorf("got %v, want 99.99 USD", a.String())
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			target, _ := currency.NewAmount(tt.target, "USD")
+			var amounts []currency.Amount
+			for _, n := range tt.numbers {
+				amount, _ := currency.NewAmount(n, "USD")
+				amounts = append(amounts, amount)
+			}
+			rounded, err := currency.RoundPreservingSum(amounts, target)
+			if tt.wantError {
+				if e, ok := err.(currency.InvalidNumberError); ok {
+					if e.Number != tt.target {
+						t.Errorf("got %v, want %v", e.Number, tt.target)
+					}
+				} else {
+					t.Errorf("got %T, want currency.InvalidNumberError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			got := []string{}
+			for _, r := range rounded {
+				got = append(got, r.Number())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
 	}
+}
 
-	// An amount equal to math.MaxInt64.
-	d, _ := currency.NewAmount("9223372036854775807", "USD")
-	e, err := d.Div("0.5")
-	if err != nil {
-		t.Errorf("unexpected error: %v", err)
+func TestAmount_Shift(t *testing.T) {
+	tests := []struct {
+		number string
+		places int
+		want   string
+	}{
+		{"12.34", 0, "12.34"},
+		{"12.34", 2, "1234"},
+		{"12.34", 4, "123400"},
+		{"5", 2, "500"},
+		{"12.34", -2, "0.1234"},
+		{"-12.34", 2, "-1234"},
+		{"-12.34", -4, "-0.001234"},
+		{"0", 2, "0"},
+
+		// Amounts larger than math.MaxInt64.
+		{"12345678901234567890.0345", 4, "123456789012345678900345"},
+		{"12345678901234567890.0345", -10, "1234567890.12345678900345"},
 	}
-	if e.String() != "18446744073709551614 USD" {
-		t.Errorf("got %v, want 18446744073709551614 USD", e.String())
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			b := a.Shift(tt.places)
+			if b.Number() != tt.want {
+				t.Errorf("got %v, want %v", b.Number(), tt.want)
+			}
+			if b.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", b.CurrencyCode())
+			}
+			// Confirm that a is unchanged.
+			if a.Number() != tt.number {
+				t.Errorf("got %v, want %v", a.Number(), tt.number)
+			}
+		})
 	}
 }
 
@@ -493,6 +1570,8 @@ func TestAmount_Round(t *testing.T) {
 	}{
 		{"12.345", "USD", "12.35"},
 		{"12.345", "JPY", "12"},
+		{"12.34565", "CLF", "12.3457"},
+		{"12.3", "CLF", "12.3000"},
 	}
 
 	for _, tt := range tests {
@@ -570,6 +1649,13 @@ func TestAmount_RoundTo(t *testing.T) {
 		{"12345678901234567890.0345", 3, currency.RoundHalfDown, "12345678901234567890.034"},
 		{"12345678901234567890.0345", 3, currency.RoundUp, "12345678901234567890.035"},
 		{"12345678901234567890.0345", 3, currency.RoundDown, "12345678901234567890.034"},
+
+		// Amounts with more than 39 significant digits.
+		{"123456789012345678901234567890123456789012345.678", 2, currency.RoundHalfUp, "123456789012345678901234567890123456789012345.68"},
+		{"123456789012345678901234567890123456789012345.678", 2, currency.RoundDown, "123456789012345678901234567890123456789012345.67"},
+		{"123456789012345678901234567890123456789012345.678", 5, currency.RoundHalfUp, "123456789012345678901234567890123456789012345.67800"},
+		{"999999999999999999999999999999999999999999999.995", 2, currency.RoundHalfUp, "1000000000000000000000000000000000000000000000.00"},
+		{"0.123456789012345678901234567890123456789012345675", 47, currency.RoundHalfEven, "0.12345678901234567890123456789012345678901234568"},
 	}
 
 	for _, tt := range tests {
@@ -587,6 +1673,123 @@ func TestAmount_RoundTo(t *testing.T) {
 	}
 }
 
+func TestAmount_WithDigits(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		digits       uint8
+		mode         currency.RoundingMode
+		want         string
+	}{
+		// Padding.
+		{"10", "USD", 2, currency.RoundHalfUp, "10.00"},
+		{"10.5", "USD", 2, currency.RoundHalfUp, "10.50"},
+		{"-10", "USD", 4, currency.RoundHalfUp, "-10.0000"},
+		{"0", "USD", 2, currency.RoundHalfUp, "0.00"},
+		{"1E2", "USD", 2, currency.RoundHalfUp, "100.00"},
+
+		// Rounding.
+		{"10.555", "USD", 2, currency.RoundHalfUp, "10.56"},
+		{"10.555", "USD", 2, currency.RoundDown, "10.55"},
+		{"10.555", "USD", 0, currency.RoundHalfUp, "11"},
+
+		// Unchanged.
+		{"10.55", "USD", 2, currency.RoundHalfUp, "10.55"},
+
+		// Currency digits.
+		{"10", "USD", currency.DefaultDigits, currency.RoundHalfUp, "10.00"},
+		{"10", "KWD", currency.DefaultDigits, currency.RoundHalfUp, "10.000"},
+		{"10.5", "JPY", currency.DefaultDigits, currency.RoundHalfEven, "10"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			b := a.WithDigits(tt.digits, tt.mode)
+			if b.Number() != tt.want {
+				t.Errorf("got %v, want %v", b.Number(), tt.want)
+			}
+			if b.String() != tt.want+" "+tt.currencyCode {
+				t.Errorf("got %v, want %v", b.String(), tt.want+" "+tt.currencyCode)
+			}
+		})
+	}
+}
+
+func TestAmount_IntegerPart(t *testing.T) {
+	tests := []struct {
+		number       string
+		wantInteger  string
+		wantFraction string
+	}{
+		{"12.99", "12.00", "0.99"},
+		{"12.00", "12.00", "0.00"},
+		{"12", "12", "0"},
+		{"0.99", "0.00", "0.99"},
+		{"0.00", "0.00", "0.00"},
+		{"-12.99", "-12.00", "-0.99"},
+		{"-12.00", "-12.00", "0.00"},
+		{"-0.99", "0.00", "-0.99"},
+		{"12.3456", "12.0000", "0.3456"},
+		{"1E3", "1000", "0"},
+		{"922337203685477598799.99", "922337203685477598799.00", "0.99"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			integer := a.IntegerPart()
+			if integer.Number() != tt.wantInteger {
+				t.Errorf("got %v, want %v", integer.Number(), tt.wantInteger)
+			}
+			if integer.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", integer.CurrencyCode())
+			}
+			fraction := a.FractionPart()
+			if fraction.Number() != tt.wantFraction {
+				t.Errorf("got %v, want %v", fraction.Number(), tt.wantFraction)
+			}
+			if fraction.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", fraction.CurrencyCode())
+			}
+			// Confirm that the parts add up to a.
+			sum, _ := integer.Add(fraction)
+			if !sum.Equal(a) {
+				t.Errorf("got %v, want %v", sum, a)
+			}
+		})
+	}
+}
+
+func TestAmount_RoundToCurrencyDigits(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		mode         currency.RoundingMode
+		want         string
+	}{
+		{"12.345", "USD", currency.RoundHalfUp, "12.35"},
+		{"12.345", "USD", currency.RoundDown, "12.34"},
+		{"12.5", "JPY", currency.RoundHalfUp, "13"},
+		{"12.5", "JPY", currency.RoundHalfEven, "12"},
+		{"12.34565", "CLF", currency.RoundHalfUp, "12.3457"},
+		{"12.3", "OMR", currency.RoundHalfUp, "12.300"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			got := a.RoundToCurrencyDigits(tt.mode)
+			if got.Number() != tt.want {
+				t.Errorf("got %v, want %v", got.Number(), tt.want)
+			}
+			// Confirm that RoundTo(DefaultDigits) is equivalent.
+			got = a.RoundTo(currency.DefaultDigits, tt.mode)
+			if got.Number() != tt.want {
+				t.Errorf("got %v, want %v", got.Number(), tt.want)
+			}
+		})
+	}
+}
+
 func TestAmount_RoundToWithConcurrency(t *testing.T) {
 	n := 2
 	roundingModes := []currency.RoundingMode{
@@ -602,26 +1805,267 @@ func TestAmount_RoundToWithConcurrency(t *testing.T) {
 		t.Run(fmt.Sprintf("rounding_mode_%d", roundingMode), func(t *testing.T) {
 			t.Parallel()
 
-			var allDone sync.WaitGroup
-			allDone.Add(n)
+			var allDone sync.WaitGroup
+			allDone.Add(n)
+
+			for i := 0; i < n; i++ {
+				go func() {
+					defer allDone.Done()
+					amount, _ := currency.NewAmount("10.99", "EUR")
+					amount.RoundTo(1, roundingMode)
+				}()
+			}
+
+			allDone.Wait()
+		})
+	}
+}
+
+func TestAmount_QuantizeTo(t *testing.T) {
+	a, _ := currency.NewAmount("12.345", "USD")
+	b, _ := currency.NewAmount("1.50", "EUR")
+	_, err := a.QuantizeTo(b, currency.RoundHalfUp)
+	if e, ok := err.(currency.MismatchError); ok {
+		if e.A != a {
+			t.Errorf("got %v, want %v", e.A, a)
+		}
+		if e.B != b {
+			t.Errorf("got %v, want %v", e.B, b)
+		}
+	} else {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
+
+	tests := []struct {
+		number    string
+		reference string
+		mode      currency.RoundingMode
+		want      string
+	}{
+		{"12.345", "1.50", currency.RoundHalfUp, "12.35"},
+		{"12.345", "1.50", currency.RoundHalfDown, "12.34"},
+		{"12.345", "1.50", currency.RoundDown, "12.34"},
+		{"-12.345", "1.50", currency.RoundHalfUp, "-12.35"},
+		{"12.345", "1", currency.RoundHalfUp, "12"},
+		{"12.345", "1.0000", currency.RoundHalfUp, "12.3450"},
+		{"12", "0.001", currency.RoundHalfUp, "12.000"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			reference, _ := currency.NewAmount(tt.reference, "USD")
+			got, err := a.QuantizeTo(reference, tt.mode)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got.Number() != tt.want {
+				t.Errorf("got %v, want %v", got.Number(), tt.want)
+			}
+			// Confirm that a is unchanged.
+			if a.Number() != tt.number {
+				t.Errorf("got %v, want %v", a.Number(), tt.number)
+			}
+		})
+	}
+}
+
+func TestAmount_QuantizeToHighPrecision(t *testing.T) {
+	a, _ := currency.NewAmount("123456789012345678901234567890123456789012345.678", "USD")
+	reference, _ := currency.NewAmount("1.00", "USD")
+	b, err := a.QuantizeTo(reference, currency.RoundHalfUp)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	want := "123456789012345678901234567890123456789012345.68"
+	if b.Number() != want {
+		t.Errorf("got %v, want %v", b.Number(), want)
+	}
+}
+
+func TestAmount_RoundToDenomination(t *testing.T) {
+	a, _ := currency.NewAmount("1.07", "CHF")
+	b, _ := currency.NewAmount("0.05", "EUR")
+	_, err := a.RoundToDenomination(b, currency.RoundHalfUp)
+	if e, ok := err.(currency.MismatchError); ok {
+		if e.A != a {
+			t.Errorf("got %v, want %v", e.A, a)
+		}
+		if e.B != b {
+			t.Errorf("got %v, want %v", e.B, b)
+		}
+	} else {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
+
+	for _, n := range []string{"0", "-0.05"} {
+		b, _ = currency.NewAmount(n, "CHF")
+		_, err = a.RoundToDenomination(b, currency.RoundHalfUp)
+		if e, ok := err.(currency.InvalidNumberError); ok {
+			if e.Number != n {
+				t.Errorf("got %v, want %v", e.Number, n)
+			}
+		} else {
+			t.Errorf("got %T, want currency.InvalidNumberError", err)
+		}
+	}
+
+	tests := []struct {
+		number   string
+		smallest string
+		mode     currency.RoundingMode
+		want     string
+	}{
+		{"1.07", "0.05", currency.RoundHalfUp, "1.05"},
+		{"1.075", "0.05", currency.RoundHalfUp, "1.10"},
+		{"1.075", "0.05", currency.RoundHalfDown, "1.05"},
+		{"1.08", "0.05", currency.RoundHalfUp, "1.10"},
+		{"1.01", "0.05", currency.RoundUp, "1.05"},
+		{"1.09", "0.05", currency.RoundDown, "1.05"},
+		{"1.10", "0.05", currency.RoundHalfUp, "1.10"},
+
+		{"1.125", "0.25", currency.RoundHalfEven, "1.00"},
+		{"1.375", "0.25", currency.RoundHalfEven, "1.50"},
+
+		{"12", "5", currency.RoundHalfUp, "10"},
+		{"13", "5", currency.RoundHalfUp, "15"},
+
+		// Negative amounts.
+		{"-1.07", "0.05", currency.RoundHalfUp, "-1.05"},
+		{"-1.08", "0.05", currency.RoundHalfUp, "-1.10"},
+		{"-1.01", "0.05", currency.RoundUp, "-1.05"},
+		{"-1.09", "0.05", currency.RoundDown, "-1.05"},
+
+		// More digits than the default precision.
+		{"123456789012345678901234567890123456789.07", "0.05", currency.RoundHalfUp, "123456789012345678901234567890123456789.05"},
+		{"123456789012345678901234567890123456789.08", "0.05", currency.RoundHalfUp, "123456789012345678901234567890123456789.10"},
+		{"-123456789012345678901234567890123456789.01", "0.05", currency.RoundUp, "-123456789012345678901234567890123456789.05"},
+		{"99999999999999999999999999999999999999999.98", "0.05", currency.RoundHalfUp, "100000000000000000000000000000000000000000.00"},
+		{"1.0000000000000000000000000000000000000000001", "0.05", currency.RoundUp, "1.05"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "CHF")
+			smallest, _ := currency.NewAmount(tt.smallest, "CHF")
+			got, err := a.RoundToDenomination(smallest, tt.mode)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got.Number() != tt.want {
+				t.Errorf("got %v, want %v", got.Number(), tt.want)
+			}
+			if got.CurrencyCode() != "CHF" {
+				t.Errorf("got %v, want CHF", got.CurrencyCode())
+			}
+		})
+	}
+}
+
+func TestAmount_Cmp(t *testing.T) {
+	a, _ := currency.NewAmount("3.33", "USD")
+	b, _ := currency.NewAmount("3.33", "EUR")
+	_, err := a.Cmp(b)
+	if e, ok := err.(currency.MismatchError); ok {
+		if e.A != a {
+			t.Errorf("got %v, want %v", e.A, a)
+		}
+		if e.B != b {
+			t.Errorf("got %v, want %v", e.B, b)
+		}
+		wantError := `amounts "3.33 USD" and "3.33 EUR" have mismatched currency codes`
+		if e.Error() != wantError {
+			t.Errorf("got %v, want %v", e.Error(), wantError)
+		}
+	} else {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
+
+	tests := []struct {
+		aNumber string
+		bNumber string
+		want    int
+	}{
+		{"3.33", "6.66", -1},
+		{"3.33", "3.33", 0},
+		{"6.66", "3.33", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.aNumber, "USD")
+			b, _ := currency.NewAmount(tt.bNumber, "USD")
+			got, err := a.Cmp(b)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_CmpString(t *testing.T) {
+	a, _ := currency.NewAmount("100.00", "USD")
+	for _, s := range []string{"", " ", "INVALID", "100.00 USD extra"} {
+		_, err := a.CmpString(s)
+		if _, ok := err.(currency.InvalidNumberError); !ok {
+			t.Errorf("%q: got %T, want currency.InvalidNumberError", s, err)
+		}
+	}
+
+	_, err := a.CmpString("100.00 usd")
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "usd" {
+			t.Errorf("got %v, want usd", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+
+	_, err = a.CmpString("100.00 EUR")
+	if e, ok := err.(currency.MismatchError); ok {
+		if e.A != a {
+			t.Errorf("got %v, want %v", e.A, a)
+		}
+		if e.B.String() != "100.00 EUR" {
+			t.Errorf("got %v, want 100.00 EUR", e.B)
+		}
+	} else {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
 
-			for i := 0; i < n; i++ {
-				go func() {
-					defer allDone.Done()
-					amount, _ := currency.NewAmount("10.99", "EUR")
-					amount.RoundTo(1, roundingMode)
-				}()
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"100.00 USD", 0},
+		{"100 USD", 0},
+		{"99.99 USD", 1},
+		{"100.01 USD", -1},
+		{"100", 0},
+		{" 50 ", 1},
+		{"150", -1},
+		{"-100.00", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := a.CmpString(tt.s)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
 			}
-
-			allDone.Wait()
 		})
 	}
 }
 
-func TestAmount_Cmp(t *testing.T) {
+func TestAmount_CmpAbs(t *testing.T) {
 	a, _ := currency.NewAmount("3.33", "USD")
-	b, _ := currency.NewAmount("3.33", "EUR")
-	_, err := a.Cmp(b)
+	b, _ := currency.NewAmount("-3.33", "EUR")
+	_, err := a.CmpAbs(b)
 	if e, ok := err.(currency.MismatchError); ok {
 		if e.A != a {
 			t.Errorf("got %v, want %v", e.A, a)
@@ -629,10 +2073,6 @@ func TestAmount_Cmp(t *testing.T) {
 		if e.B != b {
 			t.Errorf("got %v, want %v", e.B, b)
 		}
-		wantError := `amounts "3.33 USD" and "3.33 EUR" have mismatched currency codes`
-		if e.Error() != wantError {
-			t.Errorf("got %v, want %v", e.Error(), wantError)
-		}
 	} else {
 		t.Errorf("got %T, want currency.MismatchError", err)
 	}
@@ -643,15 +2083,66 @@ func TestAmount_Cmp(t *testing.T) {
 		want    int
 	}{
 		{"3.33", "6.66", -1},
+		{"3.33", "-6.66", -1},
+		{"-3.33", "6.66", -1},
 		{"3.33", "3.33", 0},
-		{"6.66", "3.33", 1},
+		{"3.33", "-3.33", 0},
+		{"-3.33", "3.33", 0},
+		{"-3.33", "-3.330", 0},
+		{"6.66", "-3.33", 1},
+		{"-6.66", "3.33", 1},
+		{"-6.66", "-3.33", 1},
 	}
 
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
 			a, _ := currency.NewAmount(tt.aNumber, "USD")
 			b, _ := currency.NewAmount(tt.bNumber, "USD")
-			got, err := a.Cmp(b)
+			got, err := a.CmpAbs(b)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_InRange(t *testing.T) {
+	min, _ := currency.NewAmount("10", "USD")
+	max, _ := currency.NewAmount("20", "USD")
+	eur, _ := currency.NewAmount("15", "EUR")
+
+	_, err := eur.InRange(min, max)
+	if _, ok := err.(currency.MismatchError); !ok {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
+	_, err = min.InRange(eur, max)
+	if _, ok := err.(currency.MismatchError); !ok {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
+	_, err = min.InRange(max, min)
+	if err != currency.ErrInvalidRange {
+		t.Errorf("got %v, want currency.ErrInvalidRange", err)
+	}
+
+	tests := []struct {
+		number string
+		want   bool
+	}{
+		{"9.99", false},
+		{"10", true},
+		{"10.00", true},
+		{"15.50", true},
+		{"20", true},
+		{"20.01", false},
+		{"-15", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			got, err := a.InRange(min, max)
 			if err != nil {
 				t.Errorf("unexpected error: %v", err)
 			}
@@ -660,6 +2151,16 @@ func TestAmount_Cmp(t *testing.T) {
 			}
 		})
 	}
+
+	// A range with a single value.
+	a, _ := currency.NewAmount("10.00", "USD")
+	got, err := a.InRange(min, min)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !got {
+		t.Errorf("got %v, want true", got)
+	}
 }
 
 func TestAmount_Equal(t *testing.T) {
@@ -674,6 +2175,12 @@ func TestAmount_Equal(t *testing.T) {
 		{"3.33", "USD", "3.33", "EUR", false},
 		{"3.33", "USD", "3.33", "USD", true},
 		{"3.33", "USD", "6.66", "USD", false},
+		// The scale is ignored.
+		{"10.5", "USD", "10.50", "USD", true},
+		{"10", "USD", "10.0000", "USD", true},
+		{"1E1", "USD", "10.00", "USD", true},
+		{"-0", "USD", "0.00", "USD", true},
+		{"10.5", "USD", "10.50", "EUR", false},
 	}
 
 	for _, tt := range tests {
@@ -688,16 +2195,226 @@ func TestAmount_Equal(t *testing.T) {
 	}
 }
 
+func TestAmount_EqualAny(t *testing.T) {
+	usd, _ := currency.NewAmount("3.33", "USD")
+	usdPadded, _ := currency.NewAmount("3.330", "USD")
+	usdOther, _ := currency.NewAmount("6.66", "USD")
+	eur, _ := currency.NewAmount("3.33", "EUR")
+	var z currency.Amount
+
+	tests := []struct {
+		a      currency.Amount
+		others []currency.Amount
+		want   bool
+	}{
+		{usd, nil, false},
+		{usd, []currency.Amount{usd}, true},
+		{usd, []currency.Amount{usdPadded}, true},
+		{usd, []currency.Amount{usdOther}, false},
+		{usd, []currency.Amount{eur}, false},
+		{usd, []currency.Amount{eur, usdOther}, false},
+		{usd, []currency.Amount{eur, usdOther, usdPadded}, true},
+		{eur, []currency.Amount{usd, usdOther}, false},
+		{z, []currency.Amount{usd, eur}, false},
+		{z, []currency.Amount{usd, z}, true},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := tt.a.EqualAny(tt.others...)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_SameValue(t *testing.T) {
+	usd, _ := currency.NewAmount("10.00", "USD")
+	eur, _ := currency.NewAmount("10", "EUR")
+	eurOther, _ := currency.NewAmount("10.01", "EUR")
+	usdNegative, _ := currency.NewAmount("-10", "USD")
+	usdZero, _ := currency.NewAmount("0", "USD")
+	var z currency.Amount
+
+	tests := []struct {
+		a    currency.Amount
+		b    currency.Amount
+		want bool
+	}{
+		{usd, usd, true},
+		{usd, eur, true},
+		{eur, usd, true},
+		{usd, eurOther, false},
+		{usd, usdNegative, false},
+		{usdZero, z, true},
+		{usd, z, false},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := tt.a.SameValue(tt.b)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_IsCompatible(t *testing.T) {
+	usd, _ := currency.NewAmount("3.33", "USD")
+	usdZero, _ := currency.NewAmount("0", "USD")
+	eur, _ := currency.NewAmount("3.33", "EUR")
+	var z currency.Amount
+
+	tests := []struct {
+		a    currency.Amount
+		b    currency.Amount
+		want bool
+	}{
+		{usd, usd, true},
+		{usd, usdZero, true},
+		{usd, eur, false},
+		{usdZero, eur, false},
+		{usd, z, true},
+		{z, eur, true},
+		{z, z, true},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := tt.a.IsCompatible(tt.b)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			// Confirm that IsCompatible mirrors Add.
+			_, err := tt.a.Add(tt.b)
+			if (err == nil) != tt.want {
+				t.Errorf("got %v from Add, want compatible %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	usdZero, _ := currency.NewAmount("0.00", "USD")
+	usd, _ := currency.NewAmount("9.99", "USD")
+	eur, _ := currency.NewAmount("8.99", "EUR")
+	var z currency.Amount
+
+	tests := []struct {
+		amounts []currency.Amount
+		want    currency.Amount
+	}{
+		{nil, z},
+		{[]currency.Amount{z, z}, z},
+		{[]currency.Amount{z, usd, eur}, usd},
+		{[]currency.Amount{eur, usd}, eur},
+		// A real zero is not the zero value.
+		{[]currency.Amount{z, usdZero, usd}, usdZero},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := currency.Coalesce(tt.amounts...)
+			if !got.Equal(tt.want) || got.String() != tt.want.String() {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxAmount(t *testing.T) {
+	a, _ := currency.NewAmount("3.45", "USD")
+	b, _ := currency.NewAmount("-12.00", "USD")
+	c, _ := currency.NewAmount("12.00", "USD")
+	x, _ := currency.NewAmount("99.99", "EUR")
+	var z currency.Amount
+
+	_, err := currency.MaxAmount(a, x)
+	if _, ok := err.(currency.MismatchError); !ok {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
+	for _, amounts := range [][]currency.Amount{nil, {z}} {
+		_, err = currency.MaxAmount(amounts...)
+		if !errors.Is(err, currency.ErrNoAmounts) {
+			t.Errorf("got %v, want currency.ErrNoAmounts", err)
+		}
+	}
+
+	tests := []struct {
+		amounts []currency.Amount
+		want    string
+	}{
+		{[]currency.Amount{a}, "3.45 USD"},
+		{[]currency.Amount{b}, "-12.00 USD"},
+		{[]currency.Amount{a, b, c}, "12.00 USD"},
+		{[]currency.Amount{b, a}, "3.45 USD"},
+		{[]currency.Amount{z, b, z}, "-12.00 USD"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got, err := currency.MaxAmount(tt.amounts...)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinAmount(t *testing.T) {
+	a, _ := currency.NewAmount("3.45", "USD")
+	b, _ := currency.NewAmount("-12.00", "USD")
+	c, _ := currency.NewAmount("12.00", "USD")
+	x, _ := currency.NewAmount("99.99", "EUR")
+	var z currency.Amount
+
+	_, err := currency.MinAmount(a, x)
+	if _, ok := err.(currency.MismatchError); !ok {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
+	for _, amounts := range [][]currency.Amount{nil, {z}} {
+		_, err = currency.MinAmount(amounts...)
+		if !errors.Is(err, currency.ErrNoAmounts) {
+			t.Errorf("got %v, want currency.ErrNoAmounts", err)
+		}
+	}
+
+	tests := []struct {
+		amounts []currency.Amount
+		want    string
+	}{
+		{[]currency.Amount{a}, "3.45 USD"},
+		{[]currency.Amount{c}, "12.00 USD"},
+		{[]currency.Amount{a, b, c}, "-12.00 USD"},
+		{[]currency.Amount{c, a}, "3.45 USD"},
+		{[]currency.Amount{z, c, z}, "12.00 USD"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got, err := currency.MinAmount(tt.amounts...)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestAmount_Checks(t *testing.T) {
 	tests := []struct {
 		number       string
 		wantPositive bool
 		wantNegative bool
 		wantZero     bool
+		wantSign     int
 	}{
-		{"9.99", true, false, false},
-		{"-9.99", false, true, false},
-		{"0", false, false, true},
+		{"9.99", true, false, false, 1},
+		{"-9.99", false, true, false, -1},
+		{"0", false, false, true, 0},
+		{"-0", false, false, true, 0},
+		{"-0.00", false, false, true, 0},
 	}
 
 	for _, tt := range tests {
@@ -715,10 +2432,147 @@ func TestAmount_Checks(t *testing.T) {
 			if gotZero != tt.wantZero {
 				t.Errorf("zero: got %v, want %v", gotZero, tt.wantZero)
 			}
+			gotSign := a.Sign()
+			if gotSign != tt.wantSign {
+				t.Errorf("sign: got %v, want %v", gotSign, tt.wantSign)
+			}
+		})
+	}
+}
+
+func TestAmount_IsSubunitPrecise(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		want         bool
+	}{
+		{"10.99", "USD", true},
+		{"10.990", "USD", true},
+		{"10.9", "USD", true},
+		{"10", "USD", true},
+		{"-10.99", "USD", true},
+		{"10.999", "USD", false},
+		{"-10.999", "USD", false},
+		{"0.001", "USD", false},
+		{"10", "JPY", true},
+		{"10.5", "JPY", false},
+		{"10.999", "KWD", true},
+		{"10.9999", "KWD", false},
+		{"1E+3", "JPY", true},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			got := a.IsSubunitPrecise()
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_IsMultipleOf(t *testing.T) {
+	a, _ := currency.NewAmount("10.99", "USD")
+	for _, increment := range []string{"INVALID", "0", "-0.05"} {
+		_, err := a.IsMultipleOf(increment)
+		if e, ok := err.(currency.InvalidNumberError); ok {
+			if e.Number != increment {
+				t.Errorf("got %v, want %v", e.Number, increment)
+			}
+		} else {
+			t.Errorf("got %T, want currency.InvalidNumberError", err)
+		}
+	}
+
+	tests := []struct {
+		number    string
+		increment string
+		want      bool
+	}{
+		{"0", "0.05", true},
+		{"1.05", "0.05", true},
+		{"1.10", "0.05", true},
+		{"1.07", "0.05", false},
+		{"-1.05", "0.05", true},
+		{"-1.07", "0.05", false},
+
+		{"0.75", "0.25", true},
+		{"10.50", "0.25", true},
+		{"0.80", "0.25", false},
+
+		{"20", "5", true},
+		{"20.00", "5", true},
+		{"25", "10", false},
+		{"100", "1", true},
+		{"100.01", "1", false},
+
+		// More quotient digits than the default precision.
+		{"1000000000", "0.0000000001", true},
+		{"1000000000.00000000005", "0.0000000001", false},
+		{"123456789012345678901234567890123456789012345", "5", true},
+		{"123456789012345678901234567890123456789012345.01", "0.000000000000000000000000000000000000000000001", true},
+		{"1e30", "0.0000000003", false},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			got, err := a.IsMultipleOf(tt.increment)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
 
+func TestAmount_SortKey(t *testing.T) {
+	// Sorted in ascending order.
+	numbers := []string{
+		"-1E+30", "-123456789012345678901234567890", "-1000", "-999.99",
+		"-12.345", "-12.34", "-12.3", "-1", "-0.5", "-0.123", "-0.12",
+		"-0.000000001", "0", "0.000000001", "0.01", "0.1", "0.12", "0.123",
+		"1", "1.00000000000000000001", "9.99", "10", "12.3", "12.34",
+		"12.345", "999.99", "1000", "123456789012345678901234567890", "1E+30",
+	}
+	var amounts []currency.Amount
+	for _, n := range numbers {
+		a, _ := currency.NewAmount(n, "USD")
+		amounts = append(amounts, a)
+	}
+	for i, a := range amounts {
+		for j, b := range amounts {
+			want, _ := a.Cmp(b)
+			got := bytes.Compare(a.SortKey(), b.SortKey())
+			if got != want {
+				t.Errorf("%v, %v: got %v, want %v", numbers[i], numbers[j], got, want)
+			}
+		}
+	}
+
+	// Numerically equal amounts have equal keys.
+	a, _ := currency.NewAmount("1.5", "USD")
+	b, _ := currency.NewAmount("1.50", "USD")
+	if !bytes.Equal(a.SortKey(), b.SortKey()) {
+		t.Errorf("got %v and %v, want equal keys", a.SortKey(), b.SortKey())
+	}
+	z, _ := currency.NewAmount("0.00", "USD")
+	if !bytes.Equal(z.SortKey(), amounts[12].SortKey()) {
+		t.Errorf("got %v and %v, want equal keys", z.SortKey(), amounts[12].SortKey())
+	}
+
+	// Keys are scoped per currency.
+	eur, _ := currency.NewAmount("-1000", "EUR")
+	if !bytes.HasPrefix(eur.SortKey(), []byte("EUR")) {
+		t.Errorf("got %v, want an EUR prefix", eur.SortKey())
+	}
+	if bytes.Compare(eur.SortKey(), amounts[0].SortKey()) != -1 {
+		t.Errorf("got %v >= %v, want EUR keys before USD keys", eur.SortKey(), amounts[0].SortKey())
+	}
+}
+
 func TestAmount_MarshalBinary(t *testing.T) {
 	a, _ := currency.NewAmount("3.45", "USD")
 	d, err := a.MarshalBinary()
@@ -800,6 +2654,41 @@ func TestAmount_MarshalJSON(t *testing.T) {
 	if got != want {
 		t.Errorf("got %v, want %v", got, want)
 	}
+
+	// Confirm that the output is byte-exact.
+	tests := []struct {
+		number       string
+		currencyCode string
+		want         string
+	}{
+		{"-3.45", "USD", `{"number":"-3.45","currency":"USD"}`},
+		{"3.450", "USD", `{"number":"3.450","currency":"USD"}`},
+		{"1000", "JPY", `{"number":"1000","currency":"JPY"}`},
+		{"0.00000001", "USD", `{"number":"0.00000001","currency":"USD"}`},
+		{"1E3", "USD", `{"number":"1000","currency":"USD"}`},
+		{"922337203685477598799", "USD", `{"number":"922337203685477598799","currency":"USD"}`},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			d, err := json.Marshal(a)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(d, []byte(tt.want)) {
+				t.Errorf("got %s, want %v", d, tt.want)
+			}
+		})
+	}
+
+	// The zero value is marshaled as null.
+	d, err = json.Marshal(currency.Amount{})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if string(d) != "null" {
+		t.Errorf("got %v, want null", string(d))
+	}
 }
 
 func TestAmount_UnmarshalJSON(t *testing.T) {
@@ -870,6 +2759,18 @@ func TestAmount_UnmarshalJSON(t *testing.T) {
 		t.Errorf("got %v, want USD", unmarshalled.CurrencyCode())
 	}
 
+	d = []byte(`null`)
+	err = json.Unmarshal(d, unmarshalled)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !unmarshalled.Equal(currency.Amount{}) {
+		t.Errorf("got %v, want the zero value", unmarshalled)
+	}
+	if unmarshalled.CurrencyCode() != "" {
+		t.Errorf("got %v, want empty currency code", unmarshalled.CurrencyCode())
+	}
+
 	d = []byte(`{'break_please'}`)
 	amount := &currency.Amount{}
 	err = amount.UnmarshalJSON(d)
@@ -904,6 +2805,7 @@ func TestAmount_Scan(t *testing.T) {
 	}{
 		{"", "0", "", ""},
 		{"(3.45,USD)", "3.45", "USD", ""},
+		{"(10.500,USD)", "10.500", "USD", ""},
 		{"(3.45,)", "0", "", `invalid currency code ""`},
 		{"(,USD)", "0", "", `invalid number ""`},
 		{"(0,)", "0", "", ""},
@@ -931,6 +2833,23 @@ func TestAmount_Scan(t *testing.T) {
 	}
 }
 
+func TestAmount_ScanScale(t *testing.T) {
+	a, _ := currency.NewAmount("10.5", "USD")
+
+	var b currency.Amount
+	// Simulate a numeric(19,3) column.
+	_ = b.Scan("(10.500,USD)")
+	if !b.Equal(a) {
+		t.Errorf("got %v, want %v", b, a)
+	}
+	if b.Number() != "10.500" {
+		t.Errorf("got %v, want 10.500", b.Number())
+	}
+	if b.Round().Number() != "10.50" {
+		t.Errorf("got %v, want 10.50", b.Round().Number())
+	}
+}
+
 func TestAmount_ScanNonString(t *testing.T) {
 	var a currency.Amount
 	err := a.Scan(123)
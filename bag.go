@@ -0,0 +1,193 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package currency
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// MissingRateError is returned when an exchange rate is missing.
+type MissingRateError struct {
+	From string
+	To   string
+}
+
+func (e MissingRateError) Error() string {
+	return fmt.Sprintf("missing exchange rate from %q to %q", e.From, e.To)
+}
+
+// Bag holds amounts in multiple currencies, e.g. the balances of a wallet.
+//
+// Amounts are summed per currency. The zero value is an empty bag, ready to use.
+type Bag struct {
+	amounts map[string]Amount
+}
+
+// Add adds an amount to the bag.
+//
+// The zero value (Amount{}) is ignored, since it has no currency.
+func (b *Bag) Add(a Amount) {
+	if a.currencyCode == "" {
+		return
+	}
+	if b.amounts == nil {
+		b.amounts = make(map[string]Amount)
+	}
+	total, ok := b.amounts[a.currencyCode]
+	if ok {
+		// The currency codes always match, so there can be no error.
+		a, _ = total.Add(a)
+	}
+	b.amounts[a.currencyCode] = a
+}
+
+// Get returns the total amount for the given currency code.
+//
+// Returns the zero value (Amount{}) if the bag has no amounts in that currency.
+func (b Bag) Get(currencyCode string) Amount {
+	return b.amounts[currencyCode]
+}
+
+// Currencies returns the currency codes of the amounts in the bag, sorted.
+func (b Bag) Currencies() []string {
+	currencyCodes := make([]string, 0, len(b.amounts))
+	for currencyCode := range b.amounts {
+		currencyCodes = append(currencyCodes, currencyCode)
+	}
+	sort.Strings(currencyCodes)
+
+	return currencyCodes
+}
+
+// Amounts returns the total amounts in the bag, sorted by currency code.
+func (b Bag) Amounts() []Amount {
+	currencyCodes := b.Currencies()
+	amounts := make([]Amount, len(currencyCodes))
+	for i, currencyCode := range currencyCodes {
+		amounts[i] = b.amounts[currencyCode]
+	}
+
+	return amounts
+}
+
+// AddBag adds the amounts from other to b, and returns the resulting bag.
+//
+// Neither b nor other are modified.
+func (b Bag) AddBag(other Bag) Bag {
+	result := b.clone()
+	for _, a := range other.amounts {
+		result.Add(a)
+	}
+
+	return result
+}
+
+// SubBag subtracts the amounts in other from b, and returns the resulting bag.
+//
+// Currencies missing from b end up with negative amounts.
+// Neither b nor other are modified.
+func (b Bag) SubBag(other Bag) Bag {
+	result := b.clone()
+	for currencyCode, a := range other.amounts {
+		// The currency codes always match, so there can be no error.
+		result.amounts[currencyCode], _ = result.amounts[currencyCode].Sub(a)
+	}
+
+	return result
+}
+
+// IsNonNegative returns whether all amounts in the bag are zero or positive.
+//
+// An empty bag is considered non-negative.
+func (b Bag) IsNonNegative() bool {
+	for _, a := range b.amounts {
+		if a.IsNegative() {
+			return false
+		}
+	}
+	return true
+}
+
+// Total converts all amounts in the bag to the given currency, and sums them.
+//
+// The rates map is keyed by the currency code being converted from,
+// e.g. {"EUR": "1.08"} when converting to USD. Amounts already in the
+// target currency don't need a rate. Just like with Convert,
+// the result is not rounded.
+// Returns MissingRateError if a rate is missing.
+func (b Bag) Total(currencyCode string, rates map[string]string) (Amount, error) {
+	total, err := NewAmount("0", currencyCode)
+	if err != nil {
+		return Amount{}, err
+	}
+	for _, code := range b.Currencies() {
+		a := b.amounts[code]
+		if code != currencyCode {
+			rate, ok := rates[code]
+			if !ok {
+				return Amount{}, MissingRateError{code, currencyCode}
+			}
+			a, err = a.Convert(currencyCode, rate)
+			if err != nil {
+				return Amount{}, err
+			}
+		}
+		total, _ = total.Add(a)
+	}
+
+	return total, nil
+}
+
+// clone returns a copy of b which can be modified independently.
+func (b Bag) clone() Bag {
+	amounts := make(map[string]Amount, len(b.amounts))
+	for currencyCode, a := range b.amounts {
+		amounts[currencyCode] = a
+	}
+
+	return Bag{amounts}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// The bag is marshaled as an object keyed by currency code,
+// e.g. {"EUR":"5.00","USD":"10.99"}.
+func (b Bag) MarshalJSON() ([]byte, error) {
+	numbers := make(map[string]string, len(b.amounts))
+	for currencyCode, a := range b.amounts {
+		numbers[currencyCode] = a.Number()
+	}
+
+	return json.Marshal(numbers)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (b *Bag) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*b = Bag{}
+		return nil
+	}
+	numbers := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &numbers); err != nil {
+		return err
+	}
+	bag := Bag{}
+	for currencyCode, rawNumber := range numbers {
+		var n string
+		if err := json.Unmarshal(rawNumber, &n); err != nil {
+			n = string(rawNumber)
+		}
+		a, err := NewAmount(n, currencyCode)
+		if err != nil {
+			return err
+		}
+		bag.Add(a)
+	}
+	*b = bag
+
+	return nil
+}
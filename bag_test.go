@@ -0,0 +1,297 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package currency_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/bojanz/currency"
+)
+
+func TestBag(t *testing.T) {
+	var b currency.Bag
+	if got := b.Amounts(); len(got) != 0 {
+		t.Errorf("got %v, want an empty bag", got)
+	}
+	if got := b.Get("USD"); got != (currency.Amount{}) {
+		t.Errorf("got %v, want the zero value", got)
+	}
+	if got := b.Currencies(); len(got) != 0 {
+		t.Errorf("got %v, want no currencies", got)
+	}
+
+	b.Add(currency.MustNewAmount("10.99", "USD"))
+	b.Add(currency.MustNewAmount("5", "EUR"))
+	b.Add(currency.Amount{})
+	b.Add(currency.MustNewAmount("0.01", "USD"))
+	b.Add(currency.MustNewAmount("1000", "RSD"))
+	b.Add(currency.MustNewAmount("-2.50", "EUR"))
+
+	tests := []struct {
+		currencyCode string
+		want         string
+	}{
+		{"USD", "11.00 USD"},
+		{"EUR", "2.50 EUR"},
+		{"RSD", "1000 RSD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.currencyCode, func(t *testing.T) {
+			got := b.Get(tt.currencyCode)
+			if got.String() != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	wantCurrencies := []string{"EUR", "RSD", "USD"}
+	if got := b.Currencies(); !reflect.DeepEqual(got, wantCurrencies) {
+		t.Errorf("got %v, want %v", got, wantCurrencies)
+	}
+	wantAmounts := []currency.Amount{
+		currency.MustNewAmount("2.50", "EUR"),
+		currency.MustNewAmount("1000", "RSD"),
+		currency.MustNewAmount("11.00", "USD"),
+	}
+	gotAmounts := b.Amounts()
+	if len(gotAmounts) != len(wantAmounts) {
+		t.Fatalf("got %v, want %v", gotAmounts, wantAmounts)
+	}
+	for i, a := range gotAmounts {
+		if a.String() != wantAmounts[i].String() {
+			t.Errorf("got %v, want %v", a, wantAmounts[i])
+		}
+	}
+}
+
+func TestBag_AddBag(t *testing.T) {
+	var a, b currency.Bag
+	a.Add(currency.MustNewAmount("10.99", "USD"))
+	a.Add(currency.MustNewAmount("5.00", "EUR"))
+	b.Add(currency.MustNewAmount("1.01", "USD"))
+	b.Add(currency.MustNewAmount("100", "RSD"))
+
+	c := a.AddBag(b)
+	tests := []struct {
+		currencyCode string
+		want         string
+	}{
+		{"USD", "12.00 USD"},
+		{"EUR", "5.00 EUR"},
+		{"RSD", "100 RSD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.currencyCode, func(t *testing.T) {
+			got := c.Get(tt.currencyCode)
+			if got.String() != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+	// Confirm that a and b are unchanged.
+	if got := a.Get("USD").String(); got != "10.99 USD" {
+		t.Errorf("got %v, want 10.99 USD", got)
+	}
+	if got := a.Get("RSD"); got != (currency.Amount{}) {
+		t.Errorf("got %v, want the zero value", got)
+	}
+	if got := b.Get("USD").String(); got != "1.01 USD" {
+		t.Errorf("got %v, want 1.01 USD", got)
+	}
+
+	// Adding an empty bag.
+	var z currency.Bag
+	d := z.AddBag(a)
+	if !reflect.DeepEqual(d.Amounts(), a.Amounts()) {
+		t.Errorf("got %v, want %v", d.Amounts(), a.Amounts())
+	}
+}
+
+func TestBag_SubBag(t *testing.T) {
+	var wallet, charge currency.Bag
+	wallet.Add(currency.MustNewAmount("10.99", "USD"))
+	wallet.Add(currency.MustNewAmount("5.00", "EUR"))
+	charge.Add(currency.MustNewAmount("3.99", "USD"))
+	charge.Add(currency.MustNewAmount("7.50", "EUR"))
+	charge.Add(currency.MustNewAmount("100", "RSD"))
+	if !wallet.IsNonNegative() {
+		t.Errorf("got a negative wallet %v", wallet.Amounts())
+	}
+
+	result := wallet.SubBag(charge)
+	tests := []struct {
+		currencyCode string
+		want         string
+	}{
+		{"USD", "7.00 USD"},
+		{"EUR", "-2.50 EUR"},
+		{"RSD", "-100 RSD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.currencyCode, func(t *testing.T) {
+			got := result.Get(tt.currencyCode)
+			if got.String() != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if result.IsNonNegative() {
+		t.Errorf("got a non-negative result %v", result.Amounts())
+	}
+	// Confirm that the wallet is unchanged.
+	if got := wallet.Get("EUR").String(); got != "5.00 EUR" {
+		t.Errorf("got %v, want 5.00 EUR", got)
+	}
+
+	// Subtracting the whole wallet leaves zero amounts.
+	result = wallet.SubBag(wallet)
+	if !result.IsNonNegative() {
+		t.Errorf("got a negative result %v", result.Amounts())
+	}
+	if got := result.Get("USD").String(); got != "0.00 USD" {
+		t.Errorf("got %v, want 0.00 USD", got)
+	}
+
+	var z currency.Bag
+	if !z.IsNonNegative() {
+		t.Errorf("got a negative empty bag")
+	}
+}
+
+func TestBag_Total(t *testing.T) {
+	var b currency.Bag
+	b.Add(currency.MustNewAmount("10.99", "USD"))
+	b.Add(currency.MustNewAmount("5.00", "EUR"))
+	b.Add(currency.MustNewAmount("1000", "RSD"))
+	rates := map[string]string{
+		"EUR": "1.08",
+		"RSD": "0.0092",
+	}
+
+	_, err := b.Total("usd", rates)
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "usd" {
+			t.Errorf("got %v, want usd", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+
+	_, err = b.Total("USD", map[string]string{"EUR": "1.08"})
+	if e, ok := err.(currency.MissingRateError); ok {
+		if e.From != "RSD" {
+			t.Errorf("got %v, want RSD", e.From)
+		}
+		if e.To != "USD" {
+			t.Errorf("got %v, want USD", e.To)
+		}
+		wantError := `missing exchange rate from "RSD" to "USD"`
+		if e.Error() != wantError {
+			t.Errorf("got %v, want %v", e.Error(), wantError)
+		}
+	} else {
+		t.Errorf("got %T, want currency.MissingRateError", err)
+	}
+
+	_, err = b.Total("USD", map[string]string{"EUR": "1.08", "RSD": "INVALID"})
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "INVALID" {
+			t.Errorf("got %v, want INVALID", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	// 10.99 + 5.00 * 1.08 + 1000 * 0.0092 = 10.99 + 5.4 + 9.2
+	total, err := b.Total("USD", rates)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if total.String() != "25.5900 USD" {
+		t.Errorf("got %v, want 25.5900 USD", total.String())
+	}
+
+	var z currency.Bag
+	total, err = z.Total("USD", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if total.String() != "0 USD" {
+		t.Errorf("got %v, want 0 USD", total.String())
+	}
+}
+
+func TestBag_MarshalJSON(t *testing.T) {
+	var b currency.Bag
+	d, err := json.Marshal(b)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if string(d) != "{}" {
+		t.Errorf("got %s, want {}", d)
+	}
+
+	b.Add(currency.MustNewAmount("10.99", "USD"))
+	b.Add(currency.MustNewAmount("5.00", "EUR"))
+	d, err = json.Marshal(b)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	want := `{"EUR":"5.00","USD":"10.99"}`
+	if string(d) != want {
+		t.Errorf("got %s, want %v", d, want)
+	}
+}
+
+func TestBag_UnmarshalJSON(t *testing.T) {
+	var b currency.Bag
+	err := json.Unmarshal([]byte(`{"USD":"INVALID"}`), &b)
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "INVALID" {
+			t.Errorf("got %v, want INVALID", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	err = json.Unmarshal([]byte(`{"usd":"10.99"}`), &b)
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "usd" {
+			t.Errorf("got %v, want usd", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+
+	err = json.Unmarshal([]byte(`{"EUR":5,"USD":"10.99"}`), &b)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got := b.Get("USD").String(); got != "10.99 USD" {
+		t.Errorf("got %v, want 10.99 USD", got)
+	}
+	if got := b.Get("EUR").String(); got != "5 EUR" {
+		t.Errorf("got %v, want 5 EUR", got)
+	}
+
+	// Unmarshaling replaces the existing amounts.
+	err = json.Unmarshal([]byte(`{"RSD":"100"}`), &b)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	wantCurrencies := []string{"RSD"}
+	if got := b.Currencies(); !reflect.DeepEqual(got, wantCurrencies) {
+		t.Errorf("got %v, want %v", got, wantCurrencies)
+	}
+
+	err = json.Unmarshal([]byte(`null`), &b)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got := b.Amounts(); len(got) != 0 {
+		t.Errorf("got %v, want an empty bag", got)
+	}
+}
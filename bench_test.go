@@ -139,3 +139,79 @@ func BenchmarkAmount_Cmp(b *testing.B) {
 	}
 	cmpResult = z
 }
+
+var formatResult string
+
+// benchmarkFormatters returns formatters for a Latin locale, a locale
+// with localized digits, an RTL locale, and the accounting style.
+func benchmarkFormatters() []struct {
+	name      string
+	formatter *currency.Formatter
+} {
+	accounting := currency.NewFormatter(currency.NewLocale("en-US"))
+	accounting.AccountingStyle = true
+	accounting.AddPlusSign = true
+
+	return []struct {
+		name      string
+		formatter *currency.Formatter
+	}{
+		{"en-US", currency.NewFormatter(currency.NewLocale("en-US"))},
+		{"fr", currency.NewFormatter(currency.NewLocale("fr"))},
+		{"ar-EG", currency.NewFormatter(currency.NewLocale("ar-EG"))},
+		{"fa", currency.NewFormatter(currency.NewLocale("fa"))},
+		{"en-US_accounting", accounting},
+	}
+}
+
+// benchmarkAmounts returns the amounts used for Format and Parse benchmarks.
+//
+// Both signs are included, since they use different patterns
+// (e.g. parentheses for negative amounts, AddPlusSign for positive ones).
+func benchmarkAmounts() []struct {
+	name   string
+	amount currency.Amount
+} {
+	positive, _ := currency.NewAmount("1234.59", "USD")
+	negative, _ := currency.NewAmount("-1234.59", "USD")
+
+	return []struct {
+		name   string
+		amount currency.Amount
+	}{
+		{"positive", positive},
+		{"negative", negative},
+	}
+}
+
+func BenchmarkFormatter_Format(b *testing.B) {
+	for _, bf := range benchmarkFormatters() {
+		formatter := bf.formatter
+		for _, ba := range benchmarkAmounts() {
+			x := ba.amount
+			b.Run(bf.name+"/"+ba.name, func(b *testing.B) {
+				var z string
+				for n := 0; n < b.N; n++ {
+					z = formatter.Format(x)
+				}
+				formatResult = z
+			})
+		}
+	}
+}
+
+func BenchmarkFormatter_Parse(b *testing.B) {
+	for _, bf := range benchmarkFormatters() {
+		formatter := bf.formatter
+		for _, ba := range benchmarkAmounts() {
+			s := formatter.Format(ba.amount)
+			b.Run(bf.name+"/"+ba.name, func(b *testing.B) {
+				var z currency.Amount
+				for n := 0; n < b.N; n++ {
+					z, _ = formatter.Parse(s, "USD")
+				}
+				result = z
+			})
+		}
+	}
+}
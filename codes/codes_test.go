@@ -0,0 +1,27 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package codes_test
+
+import (
+	"testing"
+
+	"github.com/bojanz/currency"
+	"github.com/bojanz/currency/codes"
+)
+
+func TestCodes(t *testing.T) {
+	for _, currencyCode := range []string{codes.USD, codes.EUR, codes.JPY, codes.XAU, codes.CLF} {
+		if !currency.IsValid(currencyCode) {
+			t.Errorf("got invalid currency code %v", currencyCode)
+		}
+	}
+
+	a, err := currency.NewAmount("10.99", codes.USD)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if a.String() != "10.99 USD" {
+		t.Errorf("got %v, want 10.99 USD", a.String())
+	}
+}
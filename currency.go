@@ -4,11 +4,42 @@
 // Package currency handles currency amounts, provides currency information and formatting.
 package currency
 
-import "sort"
+import (
+	"sort"
+	"strings"
+)
 
 // DefaultDigits is a placeholder for each currency's number of fraction digits.
+//
+// It is accepted by Amount.RoundTo, Amount.WithDigits and the Formatter's
+// MinDigits/MaxDigits, which replace it with the digits of the amount's currency (e.g. 2 for USD).
 const DefaultDigits uint8 = 255
 
+// Kind represents the currency kind.
+type Kind uint8
+
+const (
+	// KindFiat is a regular currency (e.g. USD).
+	KindFiat Kind = iota
+	// KindMetal is a precious metal (e.g. XAU for gold).
+	KindMetal
+	// KindFund is a fund code or another unit of account (e.g. CLF, XDR).
+	KindFund
+	// KindCrypto is a cryptocurrency (e.g. BTC).
+	// None of the built-in currencies are cryptocurrencies.
+	KindCrypto
+)
+
+// CurrencyInfo contains information about a currency.
+type CurrencyInfo struct {
+	// NumericCode is the ISO 4217 numeric code (e.g. "840" for USD).
+	NumericCode string
+	// Digits is the number of fraction digits (e.g. 2 for USD).
+	Digits uint8
+	// Kind is the currency kind (e.g. KindFiat for USD).
+	Kind Kind
+}
+
 // ForCountryCode returns the currency code for a country code.
 func ForCountryCode(countryCode string) (currencyCode string, ok bool) {
 	currencyCode, ok = countryCurrencies[countryCode]
@@ -16,11 +47,46 @@ func ForCountryCode(countryCode string) (currencyCode string, ok bool) {
 	return currencyCode, ok
 }
 
+// ForCountryCodeAny returns the currency code for an ISO 3166-1 country code.
+//
+// Accepts alpha-2 ("US"), alpha-3 ("USA") and numeric ("840") country codes,
+// for joining against datasets which don't use alpha-2 codes.
+func ForCountryCodeAny(countryCode string) (currencyCode string, ok bool) {
+	if alpha2, ok := countryCodes[countryCode]; ok {
+		countryCode = alpha2
+	}
+
+	return ForCountryCode(countryCode)
+}
+
 // GetCurrencyCodes returns all known currency codes.
+//
+// Includes the ISO 4217 fund codes (e.g. BOV, CLF, USN) and the X-codes for
+// precious metals (XAU, XAG, XPT, XPD), bond market units (XBA, XBB, XBC, XBD),
+// the SDR (XDR), the SUCRE (XSU) and the ADB Unit of Account (XUA). ISO defines
+// no minor units for the latter, so they are assumed to have 2 fraction digits.
+// The testing (XTS) and "no currency" (XXX) codes are not included.
 func GetCurrencyCodes() []string {
 	return currencyCodes
 }
 
+// EachCurrency calls fn for each known currency, in the GetCurrencyCodes order.
+//
+// Iteration stops when fn returns false.
+func EachCurrency(fn func(currencyCode string, info CurrencyInfo) bool) {
+	for _, currencyCode := range currencyCodes {
+		c := currencies[currencyCode]
+		info := CurrencyInfo{
+			NumericCode: c.numericCode,
+			Digits:      c.digits,
+			Kind:        currencyKinds[currencyCode],
+		}
+		if !fn(currencyCode, info) {
+			break
+		}
+	}
+}
+
 // IsValid checks whether a currency code is valid.
 //
 // An empty currency code is considered valid.
@@ -33,6 +99,36 @@ func IsValid(currencyCode string) bool {
 	return ok
 }
 
+// SuggestCurrencyCode suggests a known currency code for the given input.
+//
+// Meant for improving error messages when currency codes are entered manually.
+// The input is uppercased, and digits resembling letters are replaced
+// (e.g. "0" with "O"), before looking for a code at most one edit away
+// (insertion, deletion, substitution or transposition of adjacent letters).
+// For example, "usd" and "USO" both suggest "USD", and "EURO" suggests "EUR".
+// When several codes are equally close, regular currencies win over
+// fund codes and X-codes. Returns ok=false if no code is close enough.
+func SuggestCurrencyCode(input string) (currencyCode string, ok bool) {
+	input = strings.ToUpper(strings.TrimSpace(input))
+	input = strings.NewReplacer("0", "O", "1", "I", "5", "S", "8", "B").Replace(input)
+	if input == "" {
+		return "", false
+	}
+	if IsValid(input) {
+		return input, true
+	}
+	for _, code := range currencyCodes {
+		if editDistance(input, code) > 1 {
+			continue
+		}
+		if currencyCode == "" || (currencyKinds[currencyCode] != KindFiat && currencyKinds[code] == KindFiat) {
+			currencyCode = code
+		}
+	}
+
+	return currencyCode, currencyCode != ""
+}
+
 // GetNumericCode returns the numeric code for a currency code.
 func GetNumericCode(currencyCode string) (numericCode string, ok bool) {
 	if currencyCode == "" || !IsValid(currencyCode) {
@@ -49,7 +145,21 @@ func GetDigits(currencyCode string) (digits uint8, ok bool) {
 	return currencies[currencyCode].digits, true
 }
 
+// GetKind returns the kind of a currency code.
+func GetKind(currencyCode string) (kind Kind, ok bool) {
+	if currencyCode == "" || !IsValid(currencyCode) {
+		return KindFiat, false
+	}
+	return currencyKinds[currencyCode], true
+}
+
 // GetSymbol returns the symbol for a currency code.
+//
+// The symbol is looked up for the locale and then its parent locales,
+// ending with the "en" symbol. If there is no symbol data for the currency
+// (e.g. "CHF", "XAU"), the currency code itself is returned, with ok=true.
+// Use HasSymbol to detect that case. The ok return is false only for
+// empty or unknown currency codes.
 func GetSymbol(currencyCode string, locale Locale) (symbol string, ok bool) {
 	if currencyCode == "" || !IsValid(currencyCode) {
 		return currencyCode, false
@@ -85,6 +195,50 @@ func GetSymbol(currencyCode string, locale Locale) (symbol string, ok bool) {
 	return symbol, true
 }
 
+// HasSymbol returns whether a currency code has a symbol for a locale,
+// distinct from the currency code itself.
+//
+// For example, USD has a symbol ("$"), while CHF and XAU don't,
+// so GetSymbol returns their currency codes instead. Allows apps to
+// decide whether to show the code, or to fetch a symbol elsewhere.
+func HasSymbol(currencyCode string, locale Locale) bool {
+	symbol, ok := GetSymbol(currencyCode, locale)
+
+	return ok && symbol != currencyCode
+}
+
+// GetCurrencyFormat returns the currency patterns for a locale.
+//
+// The patterns are in a simplified form of the CLDR syntax,
+// where "0.00" is a placeholder for the number and "¤" for the currency.
+// For example, the standard pattern for "en" is "¤0.00",
+// and its accounting pattern is "¤0.00;(¤0.00)".
+// The accounting pattern is empty if the locale doesn't define one.
+// Returns false for unknown locales (e.g. "xx"), which the formatter
+// would fall back to "en" for.
+func GetCurrencyFormat(locale Locale) (standard, accounting string, ok bool) {
+	format, ok := lookupFormat(locale)
+	if !ok {
+		return "", "", false
+	}
+	return format.standardPattern, format.accountingPattern, true
+}
+
+// GetSeparators returns the decimal and grouping separators for a locale.
+//
+// For example, the separators for "de" are "," and ".".
+// Note that the grouping separator is not always ASCII,
+// e.g. "fr" uses U+202F (narrow no-break space).
+// Returns false for unknown locales (e.g. "xx"), which the formatter
+// would fall back to "en" for.
+func GetSeparators(locale Locale) (decimal, grouping string, ok bool) {
+	format, ok := lookupFormat(locale)
+	if !ok {
+		return "", "", false
+	}
+	return format.decimalSeparator, format.groupingSeparator, true
+}
+
 // getFormat returns the format for a locale.
 func getFormat(locale Locale) currencyFormat {
 	// CLDR considers "en" and "en-US" to be equivalent.
@@ -110,6 +264,100 @@ func getFormat(locale Locale) currencyFormat {
 	return format
 }
 
+// lookupFormat returns the format for a locale, without the "en" fallback.
+//
+// Unlike getFormat, returns false for locales which only resolve
+// through the "en" fallback (e.g. "xx" or "zz-ZZ"), and for the empty locale.
+func lookupFormat(locale Locale) (currencyFormat, bool) {
+	language := locale.Language
+	for !locale.IsEmpty() {
+		localeID := locale.String()
+		if cf, ok := currencyFormats[localeID]; ok {
+			if localeID == "en" && language != "en" {
+				return currencyFormat{}, false
+			}
+			return cf, true
+		}
+		locale = locale.GetParent()
+	}
+
+	return currencyFormat{}, false
+}
+
+// getCurrencySpacing returns the currency spacing for a locale.
+func getCurrencySpacing(locale Locale) currencySpacing {
+	for !locale.IsEmpty() {
+		if cs, ok := currencySpacings[locale.String()]; ok {
+			return cs
+		}
+		locale = locale.GetParent()
+	}
+
+	return currencySpacing{"\u00a0", "\u00a0"}
+}
+
+// getDigits returns the number of fraction digits for a currency code.
+//
+// Unlike GetDigits, unknown but well-formed currency codes (allowed by
+// NewAmountPermissive) are assumed to have 2 fraction digits.
+func getDigits(currencyCode string) uint8 {
+	if digits, ok := GetDigits(currencyCode); ok {
+		return digits
+	}
+	if isWellFormed(currencyCode) {
+		return 2
+	}
+	return 0
+}
+
+// isWellFormed checks whether a currency code consists of 3 uppercase letters.
+func isWellFormed(currencyCode string) bool {
+	if len(currencyCode) != 3 {
+		return false
+	}
+	for i := 0; i < len(currencyCode); i++ {
+		if currencyCode[i] < 'A' || currencyCode[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// editDistance returns the edit distance between a and b.
+//
+// Adjacent transpositions count as a single edit (optimal string alignment).
+func editDistance(a, b string) int {
+	// Only the last three rows are needed for the calculation.
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && prev2[j-2]+1 < curr[j] {
+				curr[j] = prev2[j-2] + 1
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(b)]
+}
+
 // contains returns whether the sorted slice a contains x.
 // The slice must be sorted in ascending order.
 func contains(a []string, x string) bool {
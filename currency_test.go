@@ -4,6 +4,7 @@
 package currency_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/bojanz/currency"
@@ -33,6 +34,44 @@ func TestForCountryCode(t *testing.T) {
 	}
 }
 
+func TestForCountryCodeAny(t *testing.T) {
+	tests := []struct {
+		countryCode      string
+		wantCurrencyCode string
+		wantOK           bool
+	}{
+		{"", "", false},
+		{"FR", "EUR", true},
+		{"FRA", "EUR", true},
+		{"250", "EUR", true},
+		{"US", "USD", true},
+		{"USA", "USD", true},
+		{"840", "USD", true},
+		{"RS", "RSD", true},
+		{"SRB", "RSD", true},
+		{"688", "RSD", true},
+		// Not an officially assigned ISO 3166-1 code, so only alpha-2 works.
+		{"XK", "EUR", true},
+		{"XKK", "", false},
+		{"XX", "", false},
+		{"XXX", "", false},
+		{"999", "", false},
+		{"usa", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.countryCode, func(t *testing.T) {
+			gotCurrencyCode, gotOK := currency.ForCountryCodeAny(tt.countryCode)
+			if gotOK != tt.wantOK {
+				t.Errorf("got %v, want %v", gotOK, tt.wantOK)
+			}
+			if gotCurrencyCode != tt.wantCurrencyCode {
+				t.Errorf("got %q, want %q", gotCurrencyCode, tt.wantCurrencyCode)
+			}
+		})
+	}
+}
+
 func TestGetCurrencyCodes(t *testing.T) {
 	currencyCodes := currency.GetCurrencyCodes()
 	var got [10]string
@@ -44,6 +83,40 @@ func TestGetCurrencyCodes(t *testing.T) {
 	}
 }
 
+func TestEachCurrency(t *testing.T) {
+	var gotCodes []string
+	currency.EachCurrency(func(currencyCode string, info currency.CurrencyInfo) bool {
+		gotCodes = append(gotCodes, currencyCode)
+		wantNumericCode, _ := currency.GetNumericCode(currencyCode)
+		wantDigits, _ := currency.GetDigits(currencyCode)
+		if info.NumericCode != wantNumericCode {
+			t.Errorf("%v: got %v, want %v", currencyCode, info.NumericCode, wantNumericCode)
+		}
+		if info.Digits != wantDigits {
+			t.Errorf("%v: got %v, want %v", currencyCode, info.Digits, wantDigits)
+		}
+		wantKind, _ := currency.GetKind(currencyCode)
+		if info.Kind != wantKind {
+			t.Errorf("%v: got %v, want %v", currencyCode, info.Kind, wantKind)
+		}
+		return true
+	})
+	wantCodes := currency.GetCurrencyCodes()
+	if !reflect.DeepEqual(gotCodes, wantCodes) {
+		t.Errorf("got %v, want %v", gotCodes, wantCodes)
+	}
+
+	// Confirm that iteration stops when false is returned.
+	gotCodes = nil
+	currency.EachCurrency(func(currencyCode string, info currency.CurrencyInfo) bool {
+		gotCodes = append(gotCodes, currencyCode)
+		return len(gotCodes) < 3
+	})
+	if !reflect.DeepEqual(gotCodes, []string{"AUD", "CAD", "CHF"}) {
+		t.Errorf("got %v, want [AUD CAD CHF]", gotCodes)
+	}
+}
+
 func TestIsValid(t *testing.T) {
 	tests := []struct {
 		currencyCode string
@@ -55,6 +128,11 @@ func TestIsValid(t *testing.T) {
 		{"usd", false},
 		{"USD", true},
 		{"EUR", true},
+		// Fund codes and X-codes.
+		{"BOV", true},
+		{"XAU", true},
+		{"XDR", true},
+		{"XTS", false},
 	}
 
 	for _, tt := range tests {
@@ -67,6 +145,46 @@ func TestIsValid(t *testing.T) {
 	}
 }
 
+func TestSuggestCurrencyCode(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{"", "", false},
+		{"   ", "", false},
+		{"USD", "USD", true},
+		{" usd ", "USD", true},
+		// Substitution, with USD winning over the USN fund code.
+		{"USO", "USD", true},
+		{"US0", "USD", true},
+		// Insertion and deletion.
+		{"US", "USD", true},
+		{"EURO", "EUR", true},
+		{"euro", "EUR", true},
+		// Transposition.
+		{"GPB", "GBP", true},
+		// Confusable digits.
+		{"5EK", "SEK", true},
+		{"CHF1", "CHF", true},
+		// Too far away.
+		{"DOLLAR", "", false},
+		{"QQQ", "", false},
+		{"XTS", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := currency.SuggestCurrencyCode(tt.input)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if ok != tt.wantOK {
+				t.Errorf("got %v, want %v", ok, tt.wantOK)
+			}
+		})
+	}
+}
+
 func TestGetNumericCode(t *testing.T) {
 	numericCode, ok := currency.GetNumericCode("USD")
 	if !ok {
@@ -95,6 +213,15 @@ func TestGetDigits(t *testing.T) {
 		t.Errorf("got %v, want 2", digits)
 	}
 
+	// X-codes without minor units are assumed to have 2 digits.
+	digits, ok = currency.GetDigits("XAU")
+	if !ok {
+		t.Errorf("got %v, want true", ok)
+	}
+	if digits != 2 {
+		t.Errorf("got %v, want 2", digits)
+	}
+
 	// Non-existent currency code.
 	digits, ok = currency.GetDigits("XXX")
 	if ok {
@@ -105,6 +232,34 @@ func TestGetDigits(t *testing.T) {
 	}
 }
 
+func TestGetKind(t *testing.T) {
+	tests := []struct {
+		currencyCode string
+		want         currency.Kind
+		wantOk       bool
+	}{
+		{"USD", currency.KindFiat, true},
+		{"XAF", currency.KindFiat, true},
+		{"XAU", currency.KindMetal, true},
+		{"XPT", currency.KindMetal, true},
+		{"CLF", currency.KindFund, true},
+		{"XDR", currency.KindFund, true},
+		{"", currency.KindFiat, false},
+		{"XXX", currency.KindFiat, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.currencyCode, func(t *testing.T) {
+			got, ok := currency.GetKind(tt.currencyCode)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if ok != tt.wantOk {
+				t.Errorf("got %v, want %v", ok, tt.wantOk)
+			}
+		})
+	}
+}
+
 func TestGetSymbol(t *testing.T) {
 	tests := []struct {
 		currencyCode string
@@ -136,3 +291,101 @@ func TestGetSymbol(t *testing.T) {
 		})
 	}
 }
+
+func TestHasSymbol(t *testing.T) {
+	tests := []struct {
+		currencyCode string
+		localeID     string
+		want         bool
+	}{
+		{"", "en", false},
+		{"XXX", "en", false},
+		{"usd", "en", false},
+		{"USD", "en", true},
+		{"USD", "sr", true},
+		{"CAD", "de", true},
+		// No symbol data, GetSymbol returns the currency code.
+		{"CHF", "en", false},
+		{"CHF", "de-CH", false},
+		{"XAU", "en", false},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := currency.HasSymbol(tt.currencyCode, currency.NewLocale(tt.localeID))
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCurrencyFormat(t *testing.T) {
+	tests := []struct {
+		localeID       string
+		wantStandard   string
+		wantAccounting string
+	}{
+		{"en", "¤0.00", "¤0.00;(¤0.00)"},
+		{"en-US", "¤0.00", "¤0.00;(¤0.00)"},
+		{"de-CH", "¤\u00a00.00;¤-0.00", ""},
+		{"sr-Latn", "0.00\u00a0¤", "0.00\u00a0¤;(0.00\u00a0¤)"},
+		{"en-XX", "¤0.00", "¤0.00;(¤0.00)"},
+		// Unknown locales.
+		{"", "", ""},
+		{"xx", "", ""},
+		{"zz-ZZ", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.localeID, func(t *testing.T) {
+			locale := currency.NewLocale(tt.localeID)
+			gotStandard, gotAccounting, ok := currency.GetCurrencyFormat(locale)
+			wantOk := tt.wantStandard != ""
+			if ok != wantOk {
+				t.Errorf("got %v, want %v", ok, wantOk)
+			}
+			if gotStandard != tt.wantStandard {
+				t.Errorf("got %q, want %q", gotStandard, tt.wantStandard)
+			}
+			if gotAccounting != tt.wantAccounting {
+				t.Errorf("got %q, want %q", gotAccounting, tt.wantAccounting)
+			}
+		})
+	}
+}
+
+func TestGetSeparators(t *testing.T) {
+	tests := []struct {
+		localeID     string
+		wantDecimal  string
+		wantGrouping string
+	}{
+		{"en", ".", ","},
+		{"de", ",", "."},
+		{"de-CH", ".", "’"},
+		{"fr", ",", "\u202f"},
+		{"fa", "٫", "٬"},
+		// Unknown locales.
+		{"", "", ""},
+		{"xx", "", ""},
+		{"zz-ZZ", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.localeID, func(t *testing.T) {
+			locale := currency.NewLocale(tt.localeID)
+			gotDecimal, gotGrouping, ok := currency.GetSeparators(locale)
+			wantOk := tt.wantDecimal != ""
+			if ok != wantOk {
+				t.Errorf("got %v, want %v", ok, wantOk)
+			}
+			if gotDecimal != tt.wantDecimal {
+				t.Errorf("got %q, want %q", gotDecimal, tt.wantDecimal)
+			}
+			if gotGrouping != tt.wantGrouping {
+				t.Errorf("got %q, want %q", gotGrouping, tt.wantGrouping)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@
 
 package currency
 
+import "unicode"
+
 // CLDRVersion is the CLDR version from which the data is derived.
 const CLDRVersion = "46.0.0"
 
@@ -40,6 +42,14 @@ type currencyFormat struct {
 	minusSign             string
 }
 
+// currencySpacing defines the characters inserted between
+// the currency symbol and the number, if the symbol ends/starts
+// with a letter. Only locales which don't use "\u00a0" are listed.
+type currencySpacing struct {
+	beforeCurrency string
+	afterCurrency  string
+}
+
 // Defined separately to ensure consistent ordering (G10, then others).
 var currencyCodes = []string{
 	// G10 currencies https://en.wikipedia.org/wiki/G10_currencies.
@@ -60,8 +70,9 @@ var currencyCodes = []string{
 	"RSD", "RUB", "RWF", "SAR", "SBD", "SCR", "SDG", "SGD", "SHP", "SLE",
 	"SOS", "SRD", "SSP", "STN", "SVC", "SYP", "SZL", "THB", "TJS", "TMT",
 	"TND", "TOP", "TRY", "TTD", "TWD", "TZS", "UAH", "UGX", "USN", "UYI",
-	"UYU", "UYW", "UZS", "VED", "VES", "VND", "VUV", "WST", "XAF", "XCD",
-	"XOF", "XPF", "YER", "ZAR", "ZMW", "ZWG",
+	"UYU", "UYW", "UZS", "VED", "VES", "VND", "VUV", "WST", "XAF", "XAG",
+	"XAU", "XBA", "XBB", "XBC", "XBD", "XCD", "XDR", "XOF", "XPD", "XPF",
+	"XPT", "XSU", "XUA", "YER", "ZAR", "ZMW", "ZWG",
 }
 
 var currencies = map[string]currencyInfo{
@@ -118,9 +129,23 @@ var currencies = map[string]currencyInfo{
 	"UYU": {"858", 2}, "UYW": {"927", 4}, "UZS": {"860", 2},
 	"VED": {"926", 2}, "VES": {"928", 2}, "VND": {"704", 0},
 	"VUV": {"548", 0}, "WST": {"882", 2}, "XAF": {"950", 0},
-	"XCD": {"951", 2}, "XOF": {"952", 0}, "XPF": {"953", 0},
-	"YER": {"886", 2}, "ZAR": {"710", 2}, "ZMW": {"967", 2},
-	"ZWG": {"924", 2},
+	"XAG": {"961", 2}, "XAU": {"959", 2}, "XBA": {"955", 2},
+	"XBB": {"956", 2}, "XBC": {"957", 2}, "XBD": {"958", 2},
+	"XCD": {"951", 2}, "XDR": {"960", 2}, "XOF": {"952", 0},
+	"XPD": {"964", 2}, "XPF": {"953", 0}, "XPT": {"962", 2},
+	"XSU": {"994", 2}, "XUA": {"965", 2}, "YER": {"886", 2},
+	"ZAR": {"710", 2}, "ZMW": {"967", 2}, "ZWG": {"924", 2},
+}
+
+// Only currencies which aren't KindFiat are listed.
+var currencyKinds = map[string]Kind{
+	"BOV": KindFund, "CHE": KindFund, "CHW": KindFund,
+	"CLF": KindFund, "COU": KindFund, "MXV": KindFund,
+	"USN": KindFund, "UYI": KindFund, "XAG": KindMetal,
+	"XAU": KindMetal, "XBA": KindFund, "XBB": KindFund,
+	"XBC": KindFund, "XBD": KindFund, "XDR": KindFund,
+	"XPD": KindMetal, "XPT": KindMetal, "XSU": KindFund,
+	"XUA": KindFund,
 }
 
 var currencySymbols = map[string][]symbolInfo{
@@ -939,6 +964,8 @@ var currencyFormats = map[string]currencyFormat{
 	"vi":         {"0.00\u00a0¤", "", 0, 1, 3, 3, ",", ".", "+", "-"},
 }
 
+var currencySpacings = map[string]currencySpacing{}
+
 var countryCurrencies = map[string]string{
 	"AC": "SHP", "AD": "EUR", "AE": "AED", "AF": "AFN", "AG": "XCD",
 	"AI": "XCD", "AL": "ALL", "AM": "AMD", "AO": "AOA", "AR": "ARS",
@@ -993,6 +1020,109 @@ var countryCurrencies = map[string]string{
 	"ZA": "ZAR", "ZM": "ZMW", "ZW": "ZWG",
 }
 
+var countryCodes = map[string]string{
+	"004": "AF", "008": "AL", "012": "DZ", "016": "AS", "020": "AD",
+	"024": "AO", "028": "AG", "031": "AZ", "032": "AR", "036": "AU",
+	"040": "AT", "044": "BS", "048": "BH", "050": "BD", "051": "AM",
+	"052": "BB", "056": "BE", "060": "BM", "064": "BT", "068": "BO",
+	"070": "BA", "072": "BW", "074": "BV", "076": "BR", "084": "BZ",
+	"086": "IO", "090": "SB", "092": "VG", "096": "BN", "100": "BG",
+	"104": "MM", "108": "BI", "112": "BY", "116": "KH", "120": "CM",
+	"124": "CA", "132": "CV", "136": "KY", "140": "CF", "144": "LK",
+	"148": "TD", "152": "CL", "156": "CN", "158": "TW", "162": "CX",
+	"166": "CC", "170": "CO", "174": "KM", "175": "YT", "178": "CG",
+	"180": "CD", "184": "CK", "188": "CR", "191": "HR", "192": "CU",
+	"196": "CY", "203": "CZ", "204": "BJ", "208": "DK", "212": "DM",
+	"214": "DO", "218": "EC", "222": "SV", "226": "GQ", "231": "ET",
+	"232": "ER", "233": "EE", "234": "FO", "238": "FK", "239": "GS",
+	"242": "FJ", "246": "FI", "248": "AX", "250": "FR", "254": "GF",
+	"258": "PF", "260": "TF", "262": "DJ", "266": "GA", "268": "GE",
+	"270": "GM", "275": "PS", "276": "DE", "288": "GH", "292": "GI",
+	"296": "KI", "300": "GR", "304": "GL", "308": "GD", "312": "GP",
+	"316": "GU", "320": "GT", "324": "GN", "328": "GY", "332": "HT",
+	"334": "HM", "336": "VA", "340": "HN", "344": "HK", "348": "HU",
+	"352": "IS", "356": "IN", "360": "ID", "364": "IR", "368": "IQ",
+	"372": "IE", "376": "IL", "380": "IT", "384": "CI", "388": "JM",
+	"392": "JP", "398": "KZ", "400": "JO", "404": "KE", "408": "KP",
+	"410": "KR", "414": "KW", "417": "KG", "418": "LA", "422": "LB",
+	"426": "LS", "428": "LV", "430": "LR", "434": "LY", "438": "LI",
+	"440": "LT", "442": "LU", "446": "MO", "450": "MG", "454": "MW",
+	"458": "MY", "462": "MV", "466": "ML", "470": "MT", "474": "MQ",
+	"478": "MR", "480": "MU", "484": "MX", "492": "MC", "496": "MN",
+	"498": "MD", "499": "ME", "500": "MS", "504": "MA", "508": "MZ",
+	"512": "OM", "516": "NA", "520": "NR", "524": "NP", "528": "NL",
+	"531": "CW", "533": "AW", "534": "SX", "535": "BQ", "540": "NC",
+	"548": "VU", "554": "NZ", "558": "NI", "562": "NE", "566": "NG",
+	"570": "NU", "574": "NF", "578": "NO", "580": "MP", "581": "UM",
+	"583": "FM", "584": "MH", "585": "PW", "586": "PK", "591": "PA",
+	"598": "PG", "600": "PY", "604": "PE", "608": "PH", "612": "PN",
+	"616": "PL", "620": "PT", "624": "GW", "626": "TL", "630": "PR",
+	"634": "QA", "638": "RE", "642": "RO", "643": "RU", "646": "RW",
+	"652": "BL", "654": "SH", "659": "KN", "660": "AI", "662": "LC",
+	"663": "MF", "666": "PM", "670": "VC", "674": "SM", "678": "ST",
+	"682": "SA", "686": "SN", "688": "RS", "690": "SC", "694": "SL",
+	"702": "SG", "703": "SK", "704": "VN", "705": "SI", "706": "SO",
+	"710": "ZA", "716": "ZW", "724": "ES", "728": "SS", "729": "SD",
+	"732": "EH", "740": "SR", "744": "SJ", "748": "SZ", "752": "SE",
+	"756": "CH", "760": "SY", "762": "TJ", "764": "TH", "768": "TG",
+	"772": "TK", "776": "TO", "780": "TT", "784": "AE", "788": "TN",
+	"792": "TR", "795": "TM", "796": "TC", "798": "TV", "800": "UG",
+	"804": "UA", "807": "MK", "818": "EG", "826": "GB", "831": "GG",
+	"832": "JE", "833": "IM", "834": "TZ", "840": "US", "850": "VI",
+	"854": "BF", "858": "UY", "860": "UZ", "862": "VE", "876": "WF",
+	"882": "WS", "887": "YE", "894": "ZM", "ABW": "AW", "AFG": "AF",
+	"AGO": "AO", "AIA": "AI", "ALA": "AX", "ALB": "AL", "AND": "AD",
+	"ARE": "AE", "ARG": "AR", "ARM": "AM", "ASM": "AS", "ATF": "TF",
+	"ATG": "AG", "AUS": "AU", "AUT": "AT", "AZE": "AZ", "BDI": "BI",
+	"BEL": "BE", "BEN": "BJ", "BES": "BQ", "BFA": "BF", "BGD": "BD",
+	"BGR": "BG", "BHR": "BH", "BHS": "BS", "BIH": "BA", "BLM": "BL",
+	"BLR": "BY", "BLZ": "BZ", "BMU": "BM", "BOL": "BO", "BRA": "BR",
+	"BRB": "BB", "BRN": "BN", "BTN": "BT", "BVT": "BV", "BWA": "BW",
+	"CAF": "CF", "CAN": "CA", "CCK": "CC", "CHE": "CH", "CHL": "CL",
+	"CHN": "CN", "CIV": "CI", "CMR": "CM", "COD": "CD", "COG": "CG",
+	"COK": "CK", "COL": "CO", "COM": "KM", "CPV": "CV", "CRI": "CR",
+	"CUB": "CU", "CUW": "CW", "CXR": "CX", "CYM": "KY", "CYP": "CY",
+	"CZE": "CZ", "DEU": "DE", "DJI": "DJ", "DMA": "DM", "DNK": "DK",
+	"DOM": "DO", "DZA": "DZ", "ECU": "EC", "EGY": "EG", "ERI": "ER",
+	"ESH": "EH", "ESP": "ES", "EST": "EE", "ETH": "ET", "FIN": "FI",
+	"FJI": "FJ", "FLK": "FK", "FRA": "FR", "FRO": "FO", "FSM": "FM",
+	"GAB": "GA", "GBR": "GB", "GEO": "GE", "GGY": "GG", "GHA": "GH",
+	"GIB": "GI", "GIN": "GN", "GLP": "GP", "GMB": "GM", "GNB": "GW",
+	"GNQ": "GQ", "GRC": "GR", "GRD": "GD", "GRL": "GL", "GTM": "GT",
+	"GUF": "GF", "GUM": "GU", "GUY": "GY", "HKG": "HK", "HMD": "HM",
+	"HND": "HN", "HRV": "HR", "HTI": "HT", "HUN": "HU", "IDN": "ID",
+	"IMN": "IM", "IND": "IN", "IOT": "IO", "IRL": "IE", "IRN": "IR",
+	"IRQ": "IQ", "ISL": "IS", "ISR": "IL", "ITA": "IT", "JAM": "JM",
+	"JEY": "JE", "JOR": "JO", "JPN": "JP", "KAZ": "KZ", "KEN": "KE",
+	"KGZ": "KG", "KHM": "KH", "KIR": "KI", "KNA": "KN", "KOR": "KR",
+	"KWT": "KW", "LAO": "LA", "LBN": "LB", "LBR": "LR", "LBY": "LY",
+	"LCA": "LC", "LIE": "LI", "LKA": "LK", "LSO": "LS", "LTU": "LT",
+	"LUX": "LU", "LVA": "LV", "MAC": "MO", "MAF": "MF", "MAR": "MA",
+	"MCO": "MC", "MDA": "MD", "MDG": "MG", "MDV": "MV", "MEX": "MX",
+	"MHL": "MH", "MKD": "MK", "MLI": "ML", "MLT": "MT", "MMR": "MM",
+	"MNE": "ME", "MNG": "MN", "MNP": "MP", "MOZ": "MZ", "MRT": "MR",
+	"MSR": "MS", "MTQ": "MQ", "MUS": "MU", "MWI": "MW", "MYS": "MY",
+	"MYT": "YT", "NAM": "NA", "NCL": "NC", "NER": "NE", "NFK": "NF",
+	"NGA": "NG", "NIC": "NI", "NIU": "NU", "NLD": "NL", "NOR": "NO",
+	"NPL": "NP", "NRU": "NR", "NZL": "NZ", "OMN": "OM", "PAK": "PK",
+	"PAN": "PA", "PCN": "PN", "PER": "PE", "PHL": "PH", "PLW": "PW",
+	"PNG": "PG", "POL": "PL", "PRI": "PR", "PRK": "KP", "PRT": "PT",
+	"PRY": "PY", "PSE": "PS", "PYF": "PF", "QAT": "QA", "REU": "RE",
+	"ROU": "RO", "RUS": "RU", "RWA": "RW", "SAU": "SA", "SDN": "SD",
+	"SEN": "SN", "SGP": "SG", "SGS": "GS", "SHN": "SH", "SJM": "SJ",
+	"SLB": "SB", "SLE": "SL", "SLV": "SV", "SMR": "SM", "SOM": "SO",
+	"SPM": "PM", "SRB": "RS", "SSD": "SS", "STP": "ST", "SUR": "SR",
+	"SVK": "SK", "SVN": "SI", "SWE": "SE", "SWZ": "SZ", "SXM": "SX",
+	"SYC": "SC", "SYR": "SY", "TCA": "TC", "TCD": "TD", "TGO": "TG",
+	"THA": "TH", "TJK": "TJ", "TKL": "TK", "TKM": "TM", "TLS": "TL",
+	"TON": "TO", "TTO": "TT", "TUN": "TN", "TUR": "TR", "TUV": "TV",
+	"TWN": "TW", "TZA": "TZ", "UGA": "UG", "UKR": "UA", "UMI": "UM",
+	"URY": "UY", "USA": "US", "UZB": "UZ", "VAT": "VA", "VCT": "VC",
+	"VEN": "VE", "VGB": "VG", "VIR": "VI", "VNM": "VN", "VUT": "VU",
+	"WLF": "WF", "WSM": "WS", "YEM": "YE", "ZAF": "ZA", "ZMB": "ZM",
+	"ZWE": "ZW",
+}
+
 var parentLocales = map[string]string{
 	"en-150": "en-001", "en-AG": "en-001", "en-AI": "en-001",
 	"en-AT": "en-150", "en-AU": "en-001", "en-BB": "en-001",
@@ -1040,3 +1170,134 @@ var parentLocales = map[string]string{
 	"sr-Latn": "en", "yue-Hans": "en", "zh-Hant": "en",
 	"zh-Hant-MO": "zh-Hant-HK",
 }
+
+// eastAsianWide contains the characters with an East Asian Width
+// of "W" (wide) or "F" (fullwidth), as of Unicode 17.0.0.
+var eastAsianWide = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115f, Stride: 1},
+		{Lo: 0x231a, Hi: 0x231b, Stride: 1},
+		{Lo: 0x2329, Hi: 0x232a, Stride: 1},
+		{Lo: 0x23e9, Hi: 0x23ec, Stride: 1},
+		{Lo: 0x23f0, Hi: 0x23f0, Stride: 1},
+		{Lo: 0x23f3, Hi: 0x23f3, Stride: 1},
+		{Lo: 0x25fd, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2630, Hi: 0x2637, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1},
+		{Lo: 0x267f, Hi: 0x267f, Stride: 1},
+		{Lo: 0x268a, Hi: 0x268f, Stride: 1},
+		{Lo: 0x2693, Hi: 0x2693, Stride: 1},
+		{Lo: 0x26a1, Hi: 0x26a1, Stride: 1},
+		{Lo: 0x26aa, Hi: 0x26ab, Stride: 1},
+		{Lo: 0x26bd, Hi: 0x26be, Stride: 1},
+		{Lo: 0x26c4, Hi: 0x26c5, Stride: 1},
+		{Lo: 0x26ce, Hi: 0x26ce, Stride: 1},
+		{Lo: 0x26d4, Hi: 0x26d4, Stride: 1},
+		{Lo: 0x26ea, Hi: 0x26ea, Stride: 1},
+		{Lo: 0x26f2, Hi: 0x26f3, Stride: 1},
+		{Lo: 0x26f5, Hi: 0x26f5, Stride: 1},
+		{Lo: 0x26fa, Hi: 0x26fa, Stride: 1},
+		{Lo: 0x26fd, Hi: 0x26fd, Stride: 1},
+		{Lo: 0x2705, Hi: 0x2705, Stride: 1},
+		{Lo: 0x270a, Hi: 0x270b, Stride: 1},
+		{Lo: 0x2728, Hi: 0x2728, Stride: 1},
+		{Lo: 0x274c, Hi: 0x274c, Stride: 1},
+		{Lo: 0x274e, Hi: 0x274e, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27b0, Hi: 0x27b0, Stride: 1},
+		{Lo: 0x27bf, Hi: 0x27bf, Stride: 1},
+		{Lo: 0x2b1b, Hi: 0x2b1c, Stride: 1},
+		{Lo: 0x2b50, Hi: 0x2b50, Stride: 1},
+		{Lo: 0x2b55, Hi: 0x2b55, Stride: 1},
+		{Lo: 0x2e80, Hi: 0x2e99, Stride: 1},
+		{Lo: 0x2e9b, Hi: 0x2ef3, Stride: 1},
+		{Lo: 0x2f00, Hi: 0x2fd5, Stride: 1},
+		{Lo: 0x2ff0, Hi: 0x303e, Stride: 1},
+		{Lo: 0x3041, Hi: 0x3096, Stride: 1},
+		{Lo: 0x3099, Hi: 0x30ff, Stride: 1},
+		{Lo: 0x3105, Hi: 0x312f, Stride: 1},
+		{Lo: 0x3131, Hi: 0x318e, Stride: 1},
+		{Lo: 0x3190, Hi: 0x31e5, Stride: 1},
+		{Lo: 0x31ef, Hi: 0x321e, Stride: 1},
+		{Lo: 0x3220, Hi: 0x3247, Stride: 1},
+		{Lo: 0x3250, Hi: 0xa48c, Stride: 1},
+		{Lo: 0xa490, Hi: 0xa4c6, Stride: 1},
+		{Lo: 0xa960, Hi: 0xa97c, Stride: 1},
+		{Lo: 0xac00, Hi: 0xd7a3, Stride: 1},
+		{Lo: 0xf900, Hi: 0xfaff, Stride: 1},
+		{Lo: 0xfe10, Hi: 0xfe19, Stride: 1},
+		{Lo: 0xfe30, Hi: 0xfe52, Stride: 1},
+		{Lo: 0xfe54, Hi: 0xfe66, Stride: 1},
+		{Lo: 0xfe68, Hi: 0xfe6b, Stride: 1},
+		{Lo: 0xff01, Hi: 0xff60, Stride: 1},
+		{Lo: 0xffe0, Hi: 0xffe6, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x16fe0, Hi: 0x16fe4, Stride: 1},
+		{Lo: 0x16ff0, Hi: 0x16ff6, Stride: 1},
+		{Lo: 0x17000, Hi: 0x18cd5, Stride: 1},
+		{Lo: 0x18cff, Hi: 0x18d1e, Stride: 1},
+		{Lo: 0x18d80, Hi: 0x18df2, Stride: 1},
+		{Lo: 0x1aff0, Hi: 0x1aff3, Stride: 1},
+		{Lo: 0x1aff5, Hi: 0x1affb, Stride: 1},
+		{Lo: 0x1affd, Hi: 0x1affe, Stride: 1},
+		{Lo: 0x1b000, Hi: 0x1b122, Stride: 1},
+		{Lo: 0x1b132, Hi: 0x1b132, Stride: 1},
+		{Lo: 0x1b150, Hi: 0x1b152, Stride: 1},
+		{Lo: 0x1b155, Hi: 0x1b155, Stride: 1},
+		{Lo: 0x1b164, Hi: 0x1b167, Stride: 1},
+		{Lo: 0x1b170, Hi: 0x1b2fb, Stride: 1},
+		{Lo: 0x1d300, Hi: 0x1d356, Stride: 1},
+		{Lo: 0x1d360, Hi: 0x1d376, Stride: 1},
+		{Lo: 0x1f004, Hi: 0x1f004, Stride: 1},
+		{Lo: 0x1f0cf, Hi: 0x1f0cf, Stride: 1},
+		{Lo: 0x1f18e, Hi: 0x1f18e, Stride: 1},
+		{Lo: 0x1f191, Hi: 0x1f19a, Stride: 1},
+		{Lo: 0x1f200, Hi: 0x1f202, Stride: 1},
+		{Lo: 0x1f210, Hi: 0x1f23b, Stride: 1},
+		{Lo: 0x1f240, Hi: 0x1f248, Stride: 1},
+		{Lo: 0x1f250, Hi: 0x1f251, Stride: 1},
+		{Lo: 0x1f260, Hi: 0x1f265, Stride: 1},
+		{Lo: 0x1f300, Hi: 0x1f320, Stride: 1},
+		{Lo: 0x1f32d, Hi: 0x1f335, Stride: 1},
+		{Lo: 0x1f337, Hi: 0x1f37c, Stride: 1},
+		{Lo: 0x1f37e, Hi: 0x1f393, Stride: 1},
+		{Lo: 0x1f3a0, Hi: 0x1f3ca, Stride: 1},
+		{Lo: 0x1f3cf, Hi: 0x1f3d3, Stride: 1},
+		{Lo: 0x1f3e0, Hi: 0x1f3f0, Stride: 1},
+		{Lo: 0x1f3f4, Hi: 0x1f3f4, Stride: 1},
+		{Lo: 0x1f3f8, Hi: 0x1f43e, Stride: 1},
+		{Lo: 0x1f440, Hi: 0x1f440, Stride: 1},
+		{Lo: 0x1f442, Hi: 0x1f4fc, Stride: 1},
+		{Lo: 0x1f4ff, Hi: 0x1f53d, Stride: 1},
+		{Lo: 0x1f54b, Hi: 0x1f54e, Stride: 1},
+		{Lo: 0x1f550, Hi: 0x1f567, Stride: 1},
+		{Lo: 0x1f57a, Hi: 0x1f57a, Stride: 1},
+		{Lo: 0x1f595, Hi: 0x1f596, Stride: 1},
+		{Lo: 0x1f5a4, Hi: 0x1f5a4, Stride: 1},
+		{Lo: 0x1f5fb, Hi: 0x1f64f, Stride: 1},
+		{Lo: 0x1f680, Hi: 0x1f6c5, Stride: 1},
+		{Lo: 0x1f6cc, Hi: 0x1f6cc, Stride: 1},
+		{Lo: 0x1f6d0, Hi: 0x1f6d2, Stride: 1},
+		{Lo: 0x1f6d5, Hi: 0x1f6d8, Stride: 1},
+		{Lo: 0x1f6dc, Hi: 0x1f6df, Stride: 1},
+		{Lo: 0x1f6eb, Hi: 0x1f6ec, Stride: 1},
+		{Lo: 0x1f6f4, Hi: 0x1f6fc, Stride: 1},
+		{Lo: 0x1f7e0, Hi: 0x1f7eb, Stride: 1},
+		{Lo: 0x1f7f0, Hi: 0x1f7f0, Stride: 1},
+		{Lo: 0x1f90c, Hi: 0x1f93a, Stride: 1},
+		{Lo: 0x1f93c, Hi: 0x1f945, Stride: 1},
+		{Lo: 0x1f947, Hi: 0x1f9ff, Stride: 1},
+		{Lo: 0x1fa70, Hi: 0x1fa7c, Stride: 1},
+		{Lo: 0x1fa80, Hi: 0x1fa8a, Stride: 1},
+		{Lo: 0x1fa8e, Hi: 0x1fac6, Stride: 1},
+		{Lo: 0x1fac8, Hi: 0x1fac8, Stride: 1},
+		{Lo: 0x1facd, Hi: 0x1fadc, Stride: 1},
+		{Lo: 0x1fadf, Hi: 0x1faea, Stride: 1},
+		{Lo: 0x1faef, Hi: 0x1faf8, Stride: 1},
+		{Lo: 0x20000, Hi: 0x3ffff, Stride: 1},
+	},
+}
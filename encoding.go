@@ -0,0 +1,336 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package currency
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidTuple is returned when unmarshaling a TupleAmount
+// from anything other than a [number, currency] array of strings.
+var ErrInvalidTuple = errors.New("invalid amount tuple: want [number, currency]")
+
+// AuditAmount wraps an Amount to include its numeric code when marshaled to JSON.
+//
+// For example: {"number":"10.99","currency":"USD","numericCode":"840"}.
+type AuditAmount struct {
+	Amount
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (a AuditAmount) MarshalJSON() ([]byte, error) {
+	if a.currencyCode == "" {
+		return []byte("null"), nil
+	}
+	numericCode, _ := GetNumericCode(a.currencyCode)
+	return json.Marshal(&struct {
+		Number       string `json:"number"`
+		CurrencyCode string `json:"currency"`
+		NumericCode  string `json:"numericCode"`
+	}{
+		Number:       a.Number(),
+		CurrencyCode: a.CurrencyCode(),
+		NumericCode:  numericCode,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// The numeric code is optional, but must match the currency code if present.
+func (a *AuditAmount) UnmarshalJSON(data []byte) error {
+	amount := Amount{}
+	if err := amount.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	if amount.currencyCode != "" {
+		aux := struct {
+			NumericCode *string `json:"numericCode"`
+		}{}
+		if err := json.Unmarshal(data, &aux); err != nil {
+			return err
+		}
+		numericCode, _ := GetNumericCode(amount.currencyCode)
+		if aux.NumericCode != nil && *aux.NumericCode != numericCode {
+			return InvalidCurrencyCodeError{*aux.NumericCode}
+		}
+	}
+	a.Amount = amount
+
+	return nil
+}
+
+// TupleAmount wraps an Amount to marshal it to JSON as a two-element array.
+//
+// For example: ["10.99","USD"]. Used by compact protocols which mandate it.
+// The zero value is marshaled as null, just like with Amount.
+type TupleAmount struct {
+	Amount
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (a TupleAmount) MarshalJSON() ([]byte, error) {
+	if a.currencyCode == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal([2]string{a.Number(), a.CurrencyCode()})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// Returns ErrInvalidTuple if the data isn't an array of exactly two
+// strings. A null value is unmarshaled as the zero value.
+func (a *TupleAmount) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*a = TupleAmount{}
+		return nil
+	}
+	var elements []json.RawMessage
+	if err := json.Unmarshal(data, &elements); err != nil || len(elements) != 2 {
+		return ErrInvalidTuple
+	}
+	var parts [2]*string
+	for i, element := range elements {
+		if err := json.Unmarshal(element, &parts[i]); err != nil || parts[i] == nil {
+			return ErrInvalidTuple
+		}
+	}
+	amount, err := NewAmount(*parts[0], *parts[1])
+	if err != nil {
+		return err
+	}
+	a.Amount = amount
+
+	return nil
+}
+
+// ScaledAmount wraps an Amount with a number of fraction digits
+// to use instead of the currency's, e.g. for a ledger that stores
+// USD amounts with 4 fraction digits.
+//
+// Round and Formatter.FormatScaled use the carried digits. When adding
+// or subtracting, the larger number of digits wins, so precision is never
+// lost. Other methods are promoted from Amount, and return a plain Amount.
+// The digits are included when encoding to JSON, binary and SQL.
+type ScaledAmount struct {
+	Amount
+	// Digits is the number of fraction digits.
+	// Passing currency.DefaultDigits uses the currency's number of fraction digits.
+	Digits uint8
+}
+
+// NewScaledAmount creates a new ScaledAmount from a numeric string,
+// a currency code and a number of fraction digits.
+//
+// The number is not rounded, use Round for that.
+func NewScaledAmount(n, currencyCode string, digits uint8) (ScaledAmount, error) {
+	a, err := NewAmount(n, currencyCode)
+	if err != nil {
+		return ScaledAmount{}, err
+	}
+
+	return ScaledAmount{a, digits}, nil
+}
+
+// Round rounds a to its number of fraction digits, using RoundHalfUp.
+func (a ScaledAmount) Round() ScaledAmount {
+	return ScaledAmount{a.RoundTo(a.Digits, RoundHalfUp), a.Digits}
+}
+
+// Add adds a and b together and returns the result.
+//
+// The result uses the larger number of fraction digits of the two.
+func (a ScaledAmount) Add(b ScaledAmount) (ScaledAmount, error) {
+	result, err := a.Amount.Add(b.Amount)
+	if err != nil {
+		return ScaledAmount{}, err
+	}
+
+	return ScaledAmount{result, largerDigits(a, b)}, nil
+}
+
+// Sub subtracts b from a and returns the result.
+//
+// The result uses the larger number of fraction digits of the two.
+func (a ScaledAmount) Sub(b ScaledAmount) (ScaledAmount, error) {
+	result, err := a.Amount.Sub(b.Amount)
+	if err != nil {
+		return ScaledAmount{}, err
+	}
+
+	return ScaledAmount{result, largerDigits(a, b)}, nil
+}
+
+// digits returns the number of fraction digits, resolving DefaultDigits.
+func (a ScaledAmount) digits() uint8 {
+	if a.Digits == DefaultDigits {
+		return getDigits(a.currencyCode)
+	}
+	return a.Digits
+}
+
+// largerDigits returns the larger number of fraction digits of a and b.
+func largerDigits(a, b ScaledAmount) uint8 {
+	if a.digits() > b.digits() {
+		return a.digits()
+	}
+	return b.digits()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// For example: {"number":"12.3456","currency":"USD","digits":4}.
+// The digits are omitted when set to currency.DefaultDigits.
+func (a ScaledAmount) MarshalJSON() ([]byte, error) {
+	if a.currencyCode == "" {
+		return []byte("null"), nil
+	}
+	var digits *uint8
+	if a.Digits != DefaultDigits {
+		digits = &a.Digits
+	}
+	return json.Marshal(&struct {
+		Number       string `json:"number"`
+		CurrencyCode string `json:"currency"`
+		Digits       *uint8 `json:"digits,omitempty"`
+	}{
+		Number:       a.Number(),
+		CurrencyCode: a.CurrencyCode(),
+		Digits:       digits,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// Missing digits are unmarshaled as currency.DefaultDigits.
+func (a *ScaledAmount) UnmarshalJSON(data []byte) error {
+	amount := Amount{}
+	if err := amount.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	if amount.currencyCode == "" {
+		*a = ScaledAmount{}
+		return nil
+	}
+	aux := struct {
+		Digits *uint8 `json:"digits"`
+	}{}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	a.Amount = amount
+	a.Digits = DefaultDigits
+	if aux.Digits != nil {
+		a.Digits = *aux.Digits
+	}
+
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+//
+// The digits are stored in the first byte, followed by the amount.
+func (a ScaledAmount) MarshalBinary() ([]byte, error) {
+	data, err := a.Amount.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{a.Digits}, data...), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (a *ScaledAmount) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return InvalidCurrencyCodeError{""}
+	}
+	amount := Amount{}
+	if err := amount.UnmarshalBinary(data[1:]); err != nil {
+		return err
+	}
+	a.Amount = amount
+	a.Digits = data[0]
+
+	return nil
+}
+
+// Value implements the database/driver.Valuer interface.
+//
+// Allows storing scaled amounts in a PostgreSQL composite type
+// with a third (smallint) field for the digits, e.g. "(12.3456,USD,4)".
+func (a ScaledAmount) Value() (driver.Value, error) {
+	return fmt.Sprintf("(%v,%v,%v)", a.Number(), a.CurrencyCode(), a.Digits), nil
+}
+
+// Scan implements the database/sql.Scanner interface.
+//
+// Missing or NULL digits (e.g. when scanning "(12.3456,USD)")
+// are scanned as currency.DefaultDigits.
+func (a *ScaledAmount) Scan(src interface{}) error {
+	// Wire format: "(12.3456,USD,4)".
+	input, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("value is not a string: %v", src)
+	}
+	if len(input) == 0 {
+		return nil
+	}
+	values := strings.Split(strings.Trim(input, "()"), ",")
+	digits := DefaultDigits
+	if len(values) == 3 && values[2] != "" {
+		d, err := strconv.ParseUint(values[2], 10, 8)
+		if err != nil {
+			return fmt.Errorf("invalid digits: %v", values[2])
+		}
+		digits = uint8(d)
+	}
+	if len(values) == 3 {
+		values = values[:2]
+	}
+	amount := Amount{}
+	if err := amount.Scan("(" + strings.Join(values, ",") + ")"); err != nil {
+		return err
+	}
+	a.Amount = amount
+	a.Digits = digits
+
+	return nil
+}
+
+// DisplayAmount wraps an Amount to include its symbol and formatted form
+// when marshaled to JSON, saving API clients from formatting the amount.
+//
+// For example: {"number":"10.99","currency":"USD","symbol":"$","formatted":"$10.99"}.
+// Meant for read-only endpoints. The symbol and formatted form are ignored
+// when unmarshaling, so only the amount itself is restored, without the locale.
+type DisplayAmount struct {
+	Amount
+	// Locale is the locale used for the symbol and formatting.
+	Locale Locale
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (a DisplayAmount) MarshalJSON() ([]byte, error) {
+	if a.currencyCode == "" {
+		return []byte("null"), nil
+	}
+	symbol, _ := GetSymbol(a.currencyCode, a.Locale)
+	formatter := NewFormatter(a.Locale)
+	return json.Marshal(&struct {
+		Number       string `json:"number"`
+		CurrencyCode string `json:"currency"`
+		Symbol       string `json:"symbol"`
+		Formatted    string `json:"formatted"`
+	}{
+		Number:       a.Number(),
+		CurrencyCode: a.CurrencyCode(),
+		Symbol:       symbol,
+		Formatted:    formatter.Format(a.Amount),
+	})
+}
@@ -0,0 +1,379 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package currency_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/bojanz/currency"
+)
+
+func TestAuditAmount_MarshalJSON(t *testing.T) {
+	a, _ := currency.NewAmount("10.99", "USD")
+	d, err := json.Marshal(currency.AuditAmount{Amount: a})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	want := `{"number":"10.99","currency":"USD","numericCode":"840"}`
+	if string(d) != want {
+		t.Errorf("got %v, want %v", string(d), want)
+	}
+
+	d, _ = json.Marshal(currency.AuditAmount{})
+	if string(d) != "null" {
+		t.Errorf("got %v, want null", string(d))
+	}
+}
+
+func TestAuditAmount_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data      string
+		want      string
+		wantError string
+	}{
+		{`{"number":"10.99","currency":"USD","numericCode":"840"}`, "10.99 USD", ""},
+		{`{"number":"10.99","currency":"USD"}`, "10.99 USD", ""},
+		{`{"number":"10.99","currency":"USD","numericCode":"978"}`, "", `invalid currency code "978"`},
+		{`{"number":"10.99","currency":"XXX","numericCode":"840"}`, "", `invalid currency code "XXX"`},
+		{`{"number":"INVALID","currency":"USD","numericCode":"840"}`, "", `invalid number "INVALID"`},
+		{`null`, "0 ", ""},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			unmarshalled := &currency.AuditAmount{}
+			err := json.Unmarshal([]byte(tt.data), unmarshalled)
+			if tt.wantError != "" {
+				if err == nil || err.Error() != tt.wantError {
+					t.Errorf("got %v, want %v", err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if unmarshalled.String() != tt.want {
+				t.Errorf("got %v, want %v", unmarshalled.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestTupleAmount_MarshalJSON(t *testing.T) {
+	a, _ := currency.NewAmount("3.45", "USD")
+	tests := []struct {
+		a    currency.TupleAmount
+		want string
+	}{
+		{currency.TupleAmount{a}, `["3.45","USD"]`},
+		{currency.TupleAmount{}, `null`},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			d, _ := json.Marshal(tt.a)
+			got := string(d)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTupleAmount_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data      string
+		want      string
+		wantError string
+	}{
+		{`["10.99","USD"]`, "10.99 USD", ""},
+		{` [ "10.990" , "EUR" ] `, "10.990 EUR", ""},
+		{`null`, "0 ", ""},
+		{`[]`, "", currency.ErrInvalidTuple.Error()},
+		{`["10.99"]`, "", currency.ErrInvalidTuple.Error()},
+		{`["10.99","USD","840"]`, "", currency.ErrInvalidTuple.Error()},
+		{`[10.99,"USD"]`, "", currency.ErrInvalidTuple.Error()},
+		{`["10.99",840]`, "", currency.ErrInvalidTuple.Error()},
+		{`["10.99",null]`, "", currency.ErrInvalidTuple.Error()},
+		{`["INVALID","USD"]`, "", `invalid number "INVALID"`},
+		{`["10.99","usd"]`, "", `invalid currency code "usd"`},
+		{`{"number":"10.99","currency":"USD"}`, "", currency.ErrInvalidTuple.Error()},
+		{`"10.99 USD"`, "", currency.ErrInvalidTuple.Error()},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			unmarshalled := &currency.TupleAmount{}
+			err := json.Unmarshal([]byte(tt.data), unmarshalled)
+			if tt.wantError != "" {
+				if err == nil || err.Error() != tt.wantError {
+					t.Errorf("got %v, want %v", err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if unmarshalled.String() != tt.want {
+				t.Errorf("got %v, want %v", unmarshalled.String(), tt.want)
+			}
+		})
+	}
+
+	// Round trip.
+	a, _ := currency.NewAmount("1234.5000", "KWD")
+	data, _ := json.Marshal(currency.TupleAmount{a})
+	unmarshalled := currency.TupleAmount{}
+	if err := json.Unmarshal(data, &unmarshalled); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if unmarshalled.String() != a.String() {
+		t.Errorf("got %v, want %v", unmarshalled.String(), a.String())
+	}
+}
+
+func TestNewScaledAmount(t *testing.T) {
+	_, err := currency.NewScaledAmount("INVALID", "USD", 4)
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "INVALID" {
+			t.Errorf("got %v, want INVALID", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	_, err = currency.NewScaledAmount("10.99", "usd", 4)
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "usd" {
+			t.Errorf("got %v, want usd", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+
+	a, err := currency.NewScaledAmount("10.12345", "USD", 4)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if a.Number() != "10.12345" {
+		t.Errorf("got %v, want 10.12345", a.Number())
+	}
+	if a.CurrencyCode() != "USD" {
+		t.Errorf("got %v, want USD", a.CurrencyCode())
+	}
+	if a.Digits != 4 {
+		t.Errorf("got %v, want 4", a.Digits)
+	}
+}
+
+func TestScaledAmount_Round(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		digits       uint8
+		want         string
+	}{
+		{"10.12345", "USD", 4, "10.1235"},
+		{"10.5", "USD", 4, "10.5000"},
+		{"-10.12345", "USD", 4, "-10.1235"},
+		{"10.12345", "USD", 0, "10"},
+		{"10.12345", "USD", currency.DefaultDigits, "10.12"},
+		{"1000.5", "JPY", 2, "1000.50"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewScaledAmount(tt.number, tt.currencyCode, tt.digits)
+			b := a.Round()
+			if b.Number() != tt.want {
+				t.Errorf("got %v, want %v", b.Number(), tt.want)
+			}
+			if b.Digits != tt.digits {
+				t.Errorf("got %v, want %v", b.Digits, tt.digits)
+			}
+		})
+	}
+}
+
+func TestScaledAmount_AddSub(t *testing.T) {
+	a, _ := currency.NewScaledAmount("10.1234", "USD", 4)
+	b, _ := currency.NewScaledAmount("5.5", "USD", currency.DefaultDigits)
+	c, _ := currency.NewScaledAmount("1", "EUR", 4)
+
+	_, err := a.Add(c)
+	if _, ok := err.(currency.MismatchError); !ok {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
+	_, err = a.Sub(c)
+	if _, ok := err.(currency.MismatchError); !ok {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
+
+	// The larger number of digits wins, regardless of the order.
+	sum, _ := b.Add(a)
+	if sum.Number() != "15.6234" {
+		t.Errorf("got %v, want 15.6234", sum.Number())
+	}
+	if sum.Digits != 4 {
+		t.Errorf("got %v, want 4", sum.Digits)
+	}
+	diff, _ := a.Sub(b)
+	if diff.Number() != "4.6234" {
+		t.Errorf("got %v, want 4.6234", diff.Number())
+	}
+	if diff.Digits != 4 {
+		t.Errorf("got %v, want 4", diff.Digits)
+	}
+	d, _ := currency.NewScaledAmount("1", "USD", 1)
+	diff, _ = b.Sub(d)
+	if diff.Digits != 2 {
+		t.Errorf("got %v, want 2", diff.Digits)
+	}
+}
+
+func TestScaledAmount_JSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		digits   uint8
+		wantJSON string
+	}{
+		{4, `{"number":"12.3456","currency":"USD","digits":4}`},
+		{0, `{"number":"12.3456","currency":"USD","digits":0}`},
+		{currency.DefaultDigits, `{"number":"12.3456","currency":"USD"}`},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewScaledAmount("12.3456", "USD", tt.digits)
+			d, err := json.Marshal(a)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if string(d) != tt.wantJSON {
+				t.Errorf("got %v, want %v", string(d), tt.wantJSON)
+			}
+			unmarshalled := currency.ScaledAmount{}
+			if err := json.Unmarshal(d, &unmarshalled); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if unmarshalled.String() != a.String() || unmarshalled.Digits != a.Digits {
+				t.Errorf("got %v (%v digits), want %v (%v digits)", unmarshalled, unmarshalled.Digits, a, a.Digits)
+			}
+			if unmarshalled.Round().Number() != a.Round().Number() {
+				t.Errorf("got %v, want %v", unmarshalled.Round().Number(), a.Round().Number())
+			}
+		})
+	}
+
+	unmarshalled := currency.ScaledAmount{}
+	if err := json.Unmarshal([]byte(`{"number":"INVALID","currency":"USD","digits":4}`), &unmarshalled); err == nil {
+		t.Error("expected an error")
+	}
+	if err := json.Unmarshal([]byte(`null`), &unmarshalled); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	d, _ := json.Marshal(currency.ScaledAmount{})
+	if string(d) != "null" {
+		t.Errorf("got %v, want null", string(d))
+	}
+}
+
+func TestScaledAmount_BinaryRoundTrip(t *testing.T) {
+	a, _ := currency.NewScaledAmount("12.3456", "USD", 4)
+	d, err := a.MarshalBinary()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	unmarshalled := &currency.ScaledAmount{}
+	if err := unmarshalled.UnmarshalBinary(d); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if unmarshalled.String() != "12.3456 USD" || unmarshalled.Digits != 4 {
+		t.Errorf("got %v (%v digits), want 12.3456 USD (4 digits)", unmarshalled, unmarshalled.Digits)
+	}
+
+	for _, data := range [][]byte{nil, {4}, []byte("\x04USD1.2.3")} {
+		if err := unmarshalled.UnmarshalBinary(data); err == nil {
+			t.Errorf("expected an error for %q", data)
+		}
+	}
+}
+
+func TestScaledAmount_SQLRoundTrip(t *testing.T) {
+	a, _ := currency.NewScaledAmount("12.3456", "USD", 4)
+	v, _ := a.Value()
+	if v != "(12.3456,USD,4)" {
+		t.Errorf("got %v, want (12.3456,USD,4)", v)
+	}
+	scanned := &currency.ScaledAmount{}
+	if err := scanned.Scan(v); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if scanned.String() != "12.3456 USD" || scanned.Digits != 4 {
+		t.Errorf("got %v (%v digits), want 12.3456 USD (4 digits)", scanned, scanned.Digits)
+	}
+
+	tests := []struct {
+		src        string
+		wantDigits uint8
+	}{
+		{"(12.3456,USD)", currency.DefaultDigits},
+		{"(12.3456,USD,)", currency.DefaultDigits},
+		{"(12.3456,USD,0)", 0},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			scanned := &currency.ScaledAmount{}
+			if err := scanned.Scan(tt.src); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if scanned.Digits != tt.wantDigits {
+				t.Errorf("got %v, want %v", scanned.Digits, tt.wantDigits)
+			}
+		})
+	}
+
+	for _, src := range []interface{}{"(12.3456,USD,X)", "(12.3456,USD,256)", "(INVALID,USD,4)", 12} {
+		if err := scanned.Scan(src); err == nil {
+			t.Errorf("expected an error for %v", src)
+		}
+	}
+}
+
+func TestDisplayAmount_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		localeID     string
+		want         string
+	}{
+		{"10.99", "USD", "en", `{"number":"10.99","currency":"USD","symbol":"$","formatted":"$10.99"}`},
+		{"-1234.5", "USD", "en", `{"number":"-1234.5","currency":"USD","symbol":"$","formatted":"-$1,234.50"}`},
+		{"10.99", "USD", "de", `{"number":"10.99","currency":"USD","symbol":"$","formatted":"10,99\u00a0$"}`},
+		{"10.99", "USD", "sr-Latn", `{"number":"10.99","currency":"USD","symbol":"US$","formatted":"10,99\u00a0US$"}`},
+		{"10.99", "EUR", "", `{"number":"10.99","currency":"EUR","symbol":"€","formatted":"€10.99"}`},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			locale := currency.NewLocale(tt.localeID)
+			d, err := json.Marshal(currency.DisplayAmount{Amount: a, Locale: locale})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			// Compare the decoded values, since the expected JSON uses escape sequences.
+			var got, want map[string]string
+			if err := json.Unmarshal(d, &got); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+
+	d, _ := json.Marshal(currency.DisplayAmount{})
+	if string(d) != "null" {
+		t.Errorf("got %v, want null", string(d))
+	}
+}
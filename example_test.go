@@ -129,6 +129,40 @@ func ExampleAmount_RoundTo() {
 	// 12 USD
 }
 
+func ExampleAmount_Equal() {
+	firstAmount, _ := currency.NewAmount("10.5", "USD")
+	secondAmount, _ := currency.NewAmount("10.50", "USD")
+	thirdAmount, _ := currency.NewAmount("10.50", "EUR")
+	fmt.Println(firstAmount.Equal(secondAmount))
+	fmt.Println(firstAmount.Equal(thirdAmount))
+	// Comparing strings takes the scale into account.
+	fmt.Println(firstAmount.String() == secondAmount.String())
+	// Output: true
+	// false
+	// false
+}
+
+func ExampleAmount_InRange() {
+	// Plausible ranges for imported amounts, per currency.
+	ranges := map[string][2]currency.Amount{
+		"USD": {currency.MustNewAmount("0", "USD"), currency.MustNewAmount("10000", "USD")},
+		"JPY": {currency.MustNewAmount("0", "JPY"), currency.MustNewAmount("1500000", "JPY")},
+	}
+	amounts := []currency.Amount{
+		currency.MustNewAmount("99.99", "USD"),
+		currency.MustNewAmount("-5.00", "USD"),
+		currency.MustNewAmount("2000000", "JPY"),
+	}
+	for _, amount := range amounts {
+		r := ranges[amount.CurrencyCode()]
+		ok, _ := amount.InRange(r[0], r[1])
+		fmt.Println(amount, ok)
+	}
+	// Output: 99.99 USD true
+	// -5.00 USD false
+	// 2000000 JPY false
+}
+
 func ExampleNewLocale() {
 	firstLocale := currency.NewLocale("en-US")
 	fmt.Println(firstLocale)
@@ -0,0 +1,20 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package currency
+
+// SetCurrencySpacing overrides the currency spacing of a locale,
+// allowing tests to cover spacings not present in the CLDR data.
+// Returns a function which restores the previous spacing.
+func SetCurrencySpacing(localeID, before, after string) (restore func()) {
+	previous, ok := currencySpacings[localeID]
+	currencySpacings[localeID] = currencySpacing{before, after}
+
+	return func() {
+		if ok {
+			currencySpacings[localeID] = previous
+		} else {
+			delete(currencySpacings, localeID)
+		}
+	}
+}
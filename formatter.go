@@ -8,6 +8,8 @@ import (
 	"strings"
 	"unicode"
 	"unicode/utf8"
+
+	"github.com/cockroachdb/apd/v3"
 )
 
 // Display represents the currency display type.
@@ -20,6 +22,32 @@ const (
 	DisplayCode
 	// DisplayNone shows nothing, hiding the currency.
 	DisplayNone
+	// DisplayAuto shows the currency symbol if it is a single character
+	// (e.g. "$", "€"), and the currency code otherwise (e.g. "USD" instead
+	// of "US$"), reducing width variance in tables. Symbols from the SymbolMap
+	// are always shown, allowing the rule to be overridden per currency.
+	DisplayAuto
+)
+
+// Notation represents the number notation.
+type Notation uint8
+
+const (
+	// NotationStandard shows the number as-is (e.g. "0.000000012").
+	NotationStandard Notation = iota
+	// NotationScientific shows the number in scientific notation (e.g. "1.2E-8").
+	NotationScientific
+)
+
+// CodeStyle represents the style of currency codes shown by DisplayCode.
+type CodeStyle uint8
+
+const (
+	// CodeAlpha shows the alphabetic code (e.g. "USD").
+	CodeAlpha CodeStyle = iota
+	// CodeNumeric shows the numeric code (e.g. "840" for USD).
+	// Currencies without a numeric code fall back to the alphabetic code.
+	CodeNumeric
 )
 
 var localDigits = map[numberingSystem]string{
@@ -32,22 +60,50 @@ var localDigits = map[numberingSystem]string{
 
 // Formatter formats and parses currency amounts.
 type Formatter struct {
-	locale Locale
-	format currencyFormat
+	locale  Locale
+	format  currencyFormat
+	spacing currencySpacing
 	// AccountingStyle formats the amount using the accounting style.
 	// For example, "-3.00 USD" in the "en" locale is formatted as "($3.00)" instead of "-$3.00".
 	// Defaults to false.
 	AccountingStyle bool
 	// AddPlusSign inserts the plus sign in front of positive amounts.
+	// Zero amounts are shown without a sign, unless AddPlusSignToZero is set.
 	// Defaults to false.
 	AddPlusSign bool
+	// ZeroLabel is shown instead of zero amounts, when non-empty.
+	// For example, "Free" instead of "$0.00". Negative zeroes are also zero,
+	// while amounts rounded to zero by MaxDigits (e.g. "0.001") are not.
+	// Defaults to "".
+	ZeroLabel string
+	// AddPlusSignToZero makes AddPlusSign also apply to zero amounts,
+	// formatting them as "+$0.00" instead of "$0.00".
+	// Defaults to false.
+	AddPlusSignToZero bool
 	// NoGrouping turns off grouping of major digits.
 	// Defaults to false.
 	NoGrouping bool
+	// GroupFraction groups fraction digits in threes, from left to right,
+	// for readability of long fractions (e.g. "0.000 001 23" in "en").
+	// The locale's grouping separator is used if it is a space (e.g. in "fr"),
+	// to avoid confusion with the decimal separator, and a thin space otherwise.
+	// Defaults to false.
+	GroupFraction bool
+	// MinGroupingDigits specifies the minimum number of digits in the
+	// leftmost group needed for grouping to happen. For example, with a value of 2
+	// "1234" is not grouped, but "12,345" is.
+	// Defaults to the locale's value (e.g. 1 for "en", 2 for "es").
+	MinGroupingDigits uint8
 	// MinDigits specifies the minimum number of fraction digits.
 	// All zeroes past the minimum will be removed (0 => no trailing zeroes).
 	// Defaults to currency.DefaultDigits (e.g. 2 for USD, 0 for RSD).
 	MinDigits uint8
+	// TrimTrailingZeros shows the amount's actual precision, up to MaxDigits,
+	// by removing all trailing zeroes and ignoring MinDigits.
+	// For example, with MaxDigits 8, "0.10000000 USD" is formatted as "$0.1",
+	// while "0.12345678 USD" keeps all of its digits.
+	// Defaults to false.
+	TrimTrailingZeros bool
 	// MaxDigits specifies the maximum number of fraction digits.
 	// Formatted amounts will be rounded to this number of digits.
 	// Defaults to 6, so that most amounts are shown as-is (without rounding).
@@ -55,13 +111,35 @@ type Formatter struct {
 	// RoundingMode specifies how the formatted amount will be rounded.
 	// Defaults to currency.RoundHalfUp.
 	RoundingMode RoundingMode
-	// CurrencyDisplay specifies how the currency will be displayed (symbol/code/none).
+	// CurrencyDisplay specifies how the currency will be displayed (symbol/code/none/auto).
 	// Defaults to currency.DisplaySymbol.
 	CurrencyDisplay Display
+	// CodeStyle specifies how the currency code will be displayed (alpha/numeric),
+	// when CurrencyDisplay is currency.DisplayCode.
+	// Defaults to currency.CodeAlpha.
+	CodeStyle CodeStyle
+	// Notation specifies how the number will be displayed (standard/scientific).
+	// The scientific notation rounds the mantissa to MaxDigits and ignores MinDigits.
+	// Defaults to currency.NotationStandard.
+	Notation Notation
 	// SymbolMap specifies custom symbols for individual currency codes.
 	// For example, "USD": "$" means that the $ symbol will be used even if
 	// the current locale's symbol is different ("US$", "$US", etc).
 	SymbolMap map[string]string
+	// StrictParsing makes Parse reject input with ambiguous or misplaced separators.
+	// By default the separators are always interpreted according to the locale,
+	// so "1.234" is parsed as 1.234 in "en", but as 1234 in "de".
+	// With StrictParsing, a single separator followed by exactly 3 digits
+	// is rejected as ambiguous, as are grouping separators which don't
+	// match the locale's grouping sizes (e.g. "1.234,56" in "en").
+	// Defaults to false.
+	StrictParsing bool
+	// StrictDigits makes Parse reject input with more fraction digits than
+	// the currency allows (e.g. "$10.999" for USD), instead of returning
+	// an over-precise amount. The input is never rounded.
+	// Trailing zeroes are allowed, so "$10.990" is still accepted.
+	// Defaults to false.
+	StrictDigits bool
 }
 
 // NewFormatter creates a new formatter for the given locale.
@@ -69,12 +147,14 @@ func NewFormatter(locale Locale) *Formatter {
 	f := &Formatter{
 		locale:          locale,
 		format:          getFormat(locale),
+		spacing:         getCurrencySpacing(locale),
 		MinDigits:       DefaultDigits,
 		MaxDigits:       6,
 		RoundingMode:    RoundHalfUp,
 		CurrencyDisplay: DisplaySymbol,
 		SymbolMap:       make(map[string]string),
 	}
+	f.MinGroupingDigits = f.format.minGroupingDigits
 	return f
 }
 
@@ -83,62 +163,311 @@ func (f *Formatter) Locale() Locale {
 	return f.locale
 }
 
+// Clone returns a copy of the formatter, including its SymbolMap.
+//
+// The clone is independent: modifying it doesn't affect f, and vice versa.
+// Allows customizing a shared base formatter per request, without data races.
+func (f *Formatter) Clone() *Formatter {
+	clone := *f
+	if f.SymbolMap != nil {
+		clone.SymbolMap = make(map[string]string, len(f.SymbolMap))
+		for currencyCode, symbol := range f.SymbolMap {
+			clone.SymbolMap[currencyCode] = symbol
+		}
+	}
+
+	return &clone
+}
+
 // Format formats a currency amount.
 func (f *Formatter) Format(amount Amount) string {
+	formatted, _ := f.FormatWithRounded(amount)
+
+	return formatted
+}
+
+// FormatWithRounded formats a currency amount, and returns whether
+// the formatted amount was rounded (due to MaxDigits).
+//
+// Allows indicating approximate values, e.g. by prepending "≈".
+func (f *Formatter) FormatWithRounded(amount Amount) (formatted string, rounded bool) {
+	formatted, roundedAmount := f.FormatAndRound(amount)
+
+	return formatted, !roundedAmount.Equal(amount)
+}
+
+// FormatAndRound formats a currency amount, and returns the amount as shown.
+//
+// The returned amount reflects the rounding done due to MaxDigits and
+// RoundingMode, allowing it to be stored exactly as displayed.
+// It is the original amount if no rounding was needed.
+func (f *Formatter) FormatAndRound(amount Amount) (string, Amount) {
+	return f.formatWithCurrency(amount, f.formatCurrency(amount.CurrencyCode()))
+}
+
+// FormatScaled formats a scaled amount, using its number of fraction digits.
+//
+// The digits replace both MinDigits and MaxDigits, so "10.5 USD" with
+// 4 digits is formatted as "$10.5000" in the "en" locale.
+func (f *Formatter) FormatScaled(amount ScaledAmount) string {
+	scaled := *f
+	scaled.MinDigits = amount.digits()
+	scaled.MaxDigits = amount.digits()
+
+	return scaled.Format(amount.Amount)
+}
+
+// FormatWithSymbol formats a currency amount using the given symbol.
+//
+// The symbol is used for this call only, regardless of CurrencyDisplay
+// and SymbolMap, avoiding the need to modify a shared formatter.
+// An empty symbol formats the amount without a currency.
+func (f *Formatter) FormatWithSymbol(amount Amount, symbol string) string {
+	formatted, _ := f.formatWithCurrency(amount, symbol)
+
+	return formatted
+}
+
+// formatWithCurrency formats a currency amount using the given formatted currency.
+//
+// Returns the amount rounded to MaxDigits, as formatted.
+func (f *Formatter) formatWithCurrency(amount Amount, formattedCurrency string) (string, Amount) {
+	if amount.IsZero() {
+		// Format a negative zero ("-0.00") as zero.
+		amount.number.Negative = false
+		if f.ZeroLabel != "" {
+			return f.ZeroLabel, amount
+		}
+	}
 	pattern := f.getPattern(amount)
-	if amount.IsNegative() {
+	negative := amount.IsNegative()
+	if negative {
 		// The minus sign will be provided by the pattern.
 		amount, _ = amount.Mul("-1")
 	}
-	formattedNumber := f.formatNumber(amount)
-	formattedCurrency := f.formatCurrency(amount.CurrencyCode())
+	var formattedNumber string
+	var roundedAmount Amount
+	if f.Notation == NotationScientific {
+		formattedNumber, roundedAmount = f.formatScientific(amount)
+	} else {
+		formattedNumber, roundedAmount = f.formatNumber(amount)
+	}
+	if negative && !roundedAmount.IsZero() {
+		roundedAmount.number.Neg(&roundedAmount.number)
+	}
 	if formattedCurrency != "" {
-		// CLDR requires having a space between the letters
-		// in a currency symbol and adjacent numbers.
+		// CLDR requires having a space between the letters (or digits,
+		// in numeric codes) in a currency symbol and adjacent numbers.
+		// The space character is defined by the locale's currency spacing.
 		if strings.Contains(pattern, "0¤") {
 			r, _ := utf8.DecodeRuneInString(formattedCurrency)
-			if unicode.IsLetter(r) {
-				formattedCurrency = "\u00a0" + formattedCurrency
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				formattedCurrency = f.spacing.beforeCurrency + formattedCurrency
 			}
 		} else if strings.Contains(pattern, "¤0") {
 			r, _ := utf8.DecodeLastRuneInString(formattedCurrency)
-			if unicode.IsLetter(r) {
-				formattedCurrency = formattedCurrency + "\u00a0"
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				formattedCurrency = formattedCurrency + f.spacing.afterCurrency
 			}
 		}
 	}
 
-	replacements := []string{
-		"0.00", formattedNumber,
-		"+", f.format.plusSign,
-		"-", f.format.minusSign,
-	}
-	if formattedCurrency == "" {
-		// Many patterns have a non-breaking space between
-		// the number and currency, not needed in this case.
-		replacements = append(replacements, "\u00a0¤", "", "¤\u00a0", "", "¤", "")
-	} else {
-		replacements = append(replacements, "¤", formattedCurrency)
+	return f.expandPattern(pattern, formattedNumber, formattedCurrency), roundedAmount
+}
+
+// expandPattern replaces the placeholders in the given pattern.
+//
+// Equivalent to using a strings.Replacer, but without the cost of
+// building one on each call, since this is the hot path of Format.
+func (f *Formatter) expandPattern(pattern, formattedNumber, formattedCurrency string) string {
+	b := strings.Builder{}
+	b.Grow(len(pattern) + len(formattedNumber) + len(formattedCurrency))
+	for i := 0; i < len(pattern); {
+		rest := pattern[i:]
+		switch {
+		case strings.HasPrefix(rest, "0.00"):
+			b.WriteString(formattedNumber)
+			i += len("0.00")
+		case rest[0] == '+':
+			b.WriteString(f.format.plusSign)
+			i++
+		case rest[0] == '-':
+			b.WriteString(f.format.minusSign)
+			i++
+		case formattedCurrency == "" && strings.HasPrefix(rest, "\u00a0¤"):
+			// Many patterns have a non-breaking space between
+			// the number and currency, not needed in this case.
+			i += len("\u00a0¤")
+		case formattedCurrency == "" && strings.HasPrefix(rest, "¤\u00a0"):
+			i += len("¤\u00a0")
+		case strings.HasPrefix(rest, "¤"):
+			b.WriteString(formattedCurrency)
+			i += len("¤")
+		default:
+			b.WriteByte(pattern[i])
+			i++
+		}
+	}
+
+	return b.String()
+}
+
+// FormatNumber formats a number without a currency.
+//
+// Uses the locale's separators, grouping and numbering system.
+// MinDigits and MaxDigits are respected, with currency.DefaultDigits meaning 0.
+func (f *Formatter) FormatNumber(n string) (string, error) {
+	number := apd.Decimal{}
+	if _, _, err := number.SetString(n); err != nil {
+		return "", InvalidNumberError{n}
+	}
+	if number.IsZero() {
+		// Format a negative zero ("-0") as zero.
+		number.Negative = false
+	}
+	amount := Amount{number: number}
+	sign := ""
+	if amount.IsNegative() {
+		amount.number.Neg(&amount.number)
+		sign = f.format.minusSign
+	} else if f.addsPlusSign(amount) {
+		sign = f.format.plusSign
+	}
+	formattedNumber, _ := f.formatNumber(amount)
+
+	return sign + formattedNumber, nil
+}
+
+// FormatPadded formats a currency amount, right-aligned in a field of the given width.
+//
+// The width is measured using DisplayWidth, not bytes, so that multibyte
+// and fullwidth symbols (e.g. "€", "￥") are aligned correctly.
+// The formatted amount is returned unchanged if it is wider than the field.
+func (f *Formatter) FormatPadded(amount Amount, width int) string {
+	formatted := f.Format(amount)
+	n := DisplayWidth(formatted)
+	if n >= width {
+		return formatted
 	}
-	r := strings.NewReplacer(replacements...)
 
-	return r.Replace(pattern)
+	return strings.Repeat(" ", width-n) + formatted
+}
+
+// DisplayWidth returns the monospace display width of s.
+//
+// Wide and fullwidth characters (as defined by the Unicode East Asian Width
+// property) are counted as 2 columns, while combining marks and invisible
+// formatting characters (such as bidi marks) are not counted.
+// All other characters are counted as 1 column.
+func DisplayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		switch {
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+			// Zero width.
+		case unicode.Is(eastAsianWide, r):
+			width += 2
+		default:
+			width++
+		}
+	}
+
+	return width
 }
 
 // Parse parses a formatted amount.
+//
+// The currency symbol and the currency code are recognized and removed.
+// The numeric code is only recognized when the formatter displays it
+// (DisplayCode with CodeNumeric), since it can't otherwise be told
+// apart from the number itself.
+// Returns ErrEmptyAmount if s is empty or contains only whitespace.
 func (f *Formatter) Parse(s, currencyCode string) (Amount, error) {
+	if strings.TrimSpace(s) == "" {
+		return Amount{}, ErrEmptyAmount
+	}
+	n := s
+	if f.CurrencyDisplay == DisplayCode && f.CodeStyle == CodeNumeric {
+		n = f.removeNumericCode(n, currencyCode)
+	}
 	symbol, _ := GetSymbol(currencyCode, f.locale)
+	n, err := f.parseNumber(n, symbol, currencyCode)
+	if err != nil {
+		return Amount{}, InvalidNumberError{s}
+	}
+	amount, err := NewAmount(n, currencyCode)
+	if err != nil {
+		return Amount{}, err
+	}
+	if f.StrictDigits && !amount.Round().Equal(amount) {
+		return Amount{}, InvalidNumberError{s}
+	}
+
+	return amount, nil
+}
+
+// ParsePercent parses a formatted percentage, returning it as a decimal fraction.
+//
+// For example, "7,5 %" is parsed as "0.075" for the "de" locale.
+// Permille values are also supported ("7,5 ‰" is parsed as "0.0075").
+// The percent sign is optional, a number without it is treated as a percentage.
+func (f *Formatter) ParsePercent(s string) (string, error) {
+	divisor := int32(2)
+	n := s
+	for _, sign := range []string{"%", "\u066a"} {
+		n = strings.ReplaceAll(n, sign, "")
+	}
+	if n == s {
+		for _, sign := range []string{"‰", "\u0609"} {
+			n = strings.ReplaceAll(n, sign, "")
+		}
+		if n != s {
+			divisor = 3
+		}
+	}
+	// Remove the spacing around the sign (e.g. "\u202f" in "fr").
+	n = strings.TrimFunc(n, unicode.IsSpace)
+	n, err := f.parseNumber(n)
+	if err != nil {
+		return "", InvalidNumberError{s}
+	}
+	number := apd.Decimal{}
+	if _, _, err := number.SetString(n); err != nil {
+		return "", InvalidNumberError{s}
+	}
+	number.Exponent -= divisor
+
+	return number.Text('f'), nil
+}
+
+// parseNumber converts a formatted number into its canonical form ("-1234.56").
+//
+// The given currencies (e.g. the symbol and the code) are removed first,
+// together with the spacing around them, so that the spacing can't be
+// mistaken for a grouping separator (e.g. "\u00a0" in "cs").
+func (f *Formatter) parseNumber(s string, currencies ...string) (string, error) {
+	n := s
+	for _, currency := range currencies {
+		n = removeCurrency(n, currency)
+	}
+	groupingSeparator := ""
+	if f.StrictParsing {
+		// Keep the grouping separators for validation.
+		groupingSeparator = ","
+	}
 	replacements := []string{
 		f.format.decimalSeparator, ".",
-		f.format.groupingSeparator, "",
+		f.format.groupingSeparator, groupingSeparator,
 		f.format.plusSign, "+",
 		f.format.minusSign, "-",
-		symbol, "",
-		currencyCode, "",
 		"\u200e", "",
 		"\u200f", "",
+		"\u061c", "",
 		"\u00a0", "",
+		"\u2009", "",
 		" ", "",
+		f.spacing.beforeCurrency, "",
+		f.spacing.afterCurrency, "",
 	}
 	if f.format.numberingSystem != numLatn {
 		digits := localDigits[f.format.numberingSystem]
@@ -150,9 +479,226 @@ func (f *Formatter) Parse(s, currencyCode string) (Amount, error) {
 		replacements = append(replacements, "(", "-", ")", "")
 	}
 	r := strings.NewReplacer(replacements...)
-	n := r.Replace(s)
+	n = r.Replace(n)
+	if strings.HasPrefix(n, "(") && strings.HasSuffix(n, ")") {
+		// A fully parenthesized amount is unambiguously negative,
+		// even when not using the accounting style (e.g. pasted from a spreadsheet).
+		n = "-" + n[1:len(n)-1]
+	}
+	if f.StrictParsing {
+		if !f.hasValidSeparators(n) {
+			return "", InvalidNumberError{s}
+		}
+		n = strings.ReplaceAll(n, ",", "")
+	}
+
+	return n, nil
+}
+
+// removeCurrency removes all occurrences of the given currency
+// symbol or code from s, together with the spacing around them.
+func removeCurrency(s, currency string) string {
+	if currency == "" {
+		return s
+	}
+	for {
+		i := strings.Index(s, currency)
+		if i == -1 {
+			return s
+		}
+		before := strings.TrimRightFunc(s[:i], isCurrencySpacing)
+		after := strings.TrimLeftFunc(s[i+len(currency):], isCurrencySpacing)
+		s = before + after
+	}
+}
 
-	return NewAmount(n, currencyCode)
+// removeNumericCode removes the numeric code of the given currency
+// (e.g. "840") from s, together with the spacing emitted before or after it.
+//
+// Unlike symbols, numeric codes can't be told apart from the number
+// itself, so the code is only removed from the position where the locale's
+// pattern puts it, and only when followed (or preceded) by the exact
+// spacing that the pattern emits. A code separated by the grouping separator
+// is kept if it could also be a group of the number (e.g. "1 840" in "cs").
+func (f *Formatter) removeNumericCode(s, currencyCode string) string {
+	numericCode, ok := GetNumericCode(currencyCode)
+	if !ok {
+		return s
+	}
+	for _, n := range []string{"1", "-1"} {
+		amount, _ := NewAmount(n, currencyCode)
+		sample, _ := f.formatWithCurrency(amount, numericCode)
+		i := strings.Index(sample, numericCode)
+		if i == -1 {
+			continue
+		}
+		before, after := sample[:i], sample[i+len(numericCode):]
+		if strings.IndexFunc(before, unicode.IsDigit) == -1 {
+			// Leading code, e.g. "-840 1,234.00" or "840-1’234.56".
+			spacing := after[:len(after)-len(strings.TrimLeftFunc(after, isCurrencySpacing))]
+			if n, ok := f.removeLeadingCode(s, numericCode, spacing); ok {
+				return n
+			}
+		} else {
+			// Trailing code, e.g. "1.234,00 840".
+			spacing := before[len(strings.TrimRightFunc(before, isCurrencySpacing)):]
+			if n, ok := f.removeTrailingCode(s, numericCode, spacing); ok {
+				return n
+			}
+		}
+	}
+
+	return s
+}
+
+// removeLeadingCode removes the numeric code and the given spacing
+// from the start of the number in s.
+func (f *Formatter) removeLeadingCode(s, numericCode, spacing string) (string, bool) {
+	i := strings.IndexFunc(s, unicode.IsDigit)
+	if i == -1 || !strings.HasPrefix(s[i:], numericCode+spacing) {
+		return s, false
+	}
+	rest := s[i+len(numericCode)+len(spacing):]
+	end := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsDigit(r) })
+	if end == -1 {
+		end = len(rest)
+	}
+	switch {
+	case spacing == "" && end > 0:
+		// The code would continue into the number.
+		return s, false
+	case spacing == f.format.groupingSeparator && f.isGroupSize(utf8.RuneCountInString(rest[:end])):
+		// The code could be the first group of the number.
+		return s, false
+	}
+
+	return s[:i] + rest, true
+}
+
+// removeTrailingCode removes the given spacing and the numeric code
+// from the end of the number in s.
+func (f *Formatter) removeTrailingCode(s, numericCode, spacing string) (string, bool) {
+	i := strings.LastIndexFunc(s, unicode.IsDigit)
+	if i == -1 {
+		return s, false
+	}
+	_, size := utf8.DecodeRuneInString(s[i:])
+	end := i + size
+	if !strings.HasSuffix(s[:end], spacing+numericCode) {
+		return s, false
+	}
+	rest := s[:end-len(spacing)-len(numericCode)]
+	r, _ := utf8.DecodeLastRuneInString(rest)
+	switch {
+	case spacing == "" && unicode.IsDigit(r):
+		// The code would continue the number.
+		return s, false
+	case spacing == f.format.groupingSeparator && !strings.Contains(rest, f.format.decimalSeparator):
+		// The code could be the last group of the number.
+		return s, false
+	}
+
+	return rest + s[end:], true
+}
+
+// isGroupSize checks whether n digits can form a group of the number.
+func (f *Formatter) isGroupSize(n int) bool {
+	return n == int(f.format.primaryGroupingSize) || n == int(f.format.secondaryGroupingSize)
+}
+
+// isCurrencySpacing checks whether r can appear between
+// the currency and the number, e.g. a space or a direction mark.
+func isCurrencySpacing(r rune) bool {
+	return unicode.IsSpace(r) || r == '\u200e' || r == '\u200f' || r == '\u061c'
+}
+
+// hasValidSeparators checks whether the separators in the given number
+// are unambiguous and match the locale's grouping sizes.
+//
+// The number is expected to use "." as the decimal separator
+// and "," as the grouping separator.
+func (f *Formatter) hasValidSeparators(n string) bool {
+	n = strings.TrimLeft(n, "+-")
+	parts := strings.SplitN(n, ".", 2)
+	if len(parts) == 2 && strings.Contains(parts[1], ",") {
+		// Grouping separators are not allowed after the decimal separator.
+		return false
+	}
+	groups := strings.Split(parts[0], ",")
+	numGroups := len(groups)
+	if numGroups > 1 {
+		if f.format.primaryGroupingSize == 0 {
+			return false
+		}
+		// The first group can be shorter than the others.
+		maxFirstSize := int(f.format.primaryGroupingSize)
+		if numGroups > 2 {
+			maxFirstSize = int(f.format.secondaryGroupingSize)
+		}
+		if len(groups[0]) == 0 || len(groups[0]) > maxFirstSize {
+			return false
+		}
+		for i := 1; i < numGroups-1; i++ {
+			if len(groups[i]) != int(f.format.secondaryGroupingSize) {
+				return false
+			}
+		}
+		if len(groups[numGroups-1]) != int(f.format.primaryGroupingSize) {
+			return false
+		}
+	}
+
+	// A single separator followed by exactly 3 digits (e.g. "1.234" or "1,234")
+	// means 1.234 in some locales, and 1234 in others.
+	var after string
+	switch {
+	case numGroups == 2 && len(parts) == 1:
+		after = groups[1]
+	case numGroups == 1 && len(parts) == 2:
+		after = parts[1]
+	default:
+		return true
+	}
+
+	return !(len(groups[0]) <= 3 && len(after) == 3)
+}
+
+// Parse parses a formatted amount, detecting its currency.
+//
+// The currency is detected from the currency code or symbol contained in s,
+// using the symbols of the given locale. When several symbols match
+// (e.g. "$" and "US$"), the longest one wins.
+// For example: Parse("$1,234.56", "en-US") returns 1234.56 USD.
+// Returns ErrEmptyAmount if s is empty or contains only whitespace.
+func Parse(s, localeID string) (Amount, error) {
+	if strings.TrimSpace(s) == "" {
+		return Amount{}, ErrEmptyAmount
+	}
+	locale := NewLocale(localeID)
+	currencyCode, ok := detectCurrency(s, locale)
+	if !ok {
+		return Amount{}, InvalidCurrencyCodeError{""}
+	}
+
+	return NewFormatter(locale).Parse(s, currencyCode)
+}
+
+// detectCurrency detects the currency code used in a formatted amount.
+func detectCurrency(s string, locale Locale) (string, bool) {
+	currencyCode := ""
+	matchLen := 0
+	for _, code := range currencyCodes {
+		if strings.Contains(s, code) {
+			return code, true
+		}
+		symbol, _ := GetSymbol(code, locale)
+		if len(symbol) > matchLen && strings.Contains(s, symbol) {
+			currencyCode = code
+			matchLen = len(symbol)
+		}
+	}
+
+	return currencyCode, currencyCode != ""
 }
 
 // getPattern returns a positive or negative pattern for a currency amount.
@@ -167,12 +713,12 @@ func (f *Formatter) getPattern(amount Amount) string {
 	switch {
 	case amount.IsNegative():
 		if len(patterns) == 1 {
-			return "-" + patterns[0]
+			return prefixSign(patterns[0], "-")
 		}
 		return patterns[1]
-	case f.AddPlusSign:
+	case f.addsPlusSign(amount):
 		if len(patterns) == 1 || f.usesAccountingPattern() {
-			return "+" + patterns[0]
+			return prefixSign(patterns[0], "+")
 		}
 		return strings.Replace(patterns[1], "-", "+", 1)
 	default:
@@ -180,23 +726,49 @@ func (f *Formatter) getPattern(amount Amount) string {
 	}
 }
 
+// addsPlusSign returns whether the plus sign should be shown for a non-negative amount.
+func (f *Formatter) addsPlusSign(amount Amount) bool {
+	return f.AddPlusSign && (!amount.IsZero() || f.AddPlusSignToZero)
+}
+
+// prefixSign prefixes the pattern with the given sign.
+//
+// Leading bidi marks are kept in front of the sign, matching the
+// placement used by explicit negative patterns (e.g. "\u200f-0.00\u00a0¤" in "ar").
+func prefixSign(pattern, sign string) string {
+	i := 0
+	for i < len(pattern) {
+		r, size := utf8.DecodeRuneInString(pattern[i:])
+		if r != '\u200e' && r != '\u200f' && r != '\u061c' {
+			break
+		}
+		i += size
+	}
+
+	return pattern[:i] + sign + pattern[i:]
+}
+
 // usesAccountingPattern returns whether the formatter needs to use the accounting pattern.
 func (f *Formatter) usesAccountingPattern() bool {
 	return f.AccountingStyle && f.format.accountingPattern != ""
 }
 
 // formatNumber formats the number for display.
-func (f *Formatter) formatNumber(amount Amount) string {
+// Returns the number rounded to MaxDigits, or the original number if unchanged.
+func (f *Formatter) formatNumber(amount Amount) (string, Amount) {
 	minDigits := f.MinDigits
 	if minDigits == DefaultDigits {
-		minDigits, _ = GetDigits(amount.CurrencyCode())
+		minDigits = getDigits(amount.CurrencyCode())
+	}
+	if f.TrimTrailingZeros {
+		minDigits = 0
 	}
 	maxDigits := f.MaxDigits
 	if maxDigits == DefaultDigits {
-		maxDigits, _ = GetDigits(amount.CurrencyCode())
+		maxDigits = getDigits(amount.CurrencyCode())
 	}
-	amount = amount.RoundTo(maxDigits, f.RoundingMode)
-	numberParts := strings.Split(amount.Number(), ".")
+	roundedAmount := amount.RoundTo(maxDigits, f.RoundingMode)
+	numberParts := strings.Split(roundedAmount.Number(), ".")
 	majorDigits := f.groupMajorDigits(numberParts[0])
 	minorDigits := ""
 	if len(numberParts) == 2 {
@@ -211,6 +783,9 @@ func (f *Formatter) formatNumber(amount Amount) string {
 			minorDigits += strings.Repeat("0", int(minDigits)-len(minorDigits))
 		}
 	}
+	if f.GroupFraction {
+		minorDigits = f.groupMinorDigits(minorDigits)
+	}
 	b := strings.Builder{}
 	b.WriteString(majorDigits)
 	if minorDigits != "" {
@@ -218,8 +793,58 @@ func (f *Formatter) formatNumber(amount Amount) string {
 		b.WriteString(minorDigits)
 	}
 	formatted := f.localizeDigits(b.String())
+	if roundedAmount.Equal(amount) {
+		return formatted, amount
+	}
 
-	return formatted
+	return formatted, roundedAmount
+}
+
+// formatScientific formats a number in scientific notation.
+//
+// The mantissa has a single major digit, e.g. "1.2E-8" or "1.5E6".
+// Returns the number with the mantissa rounded to MaxDigits,
+// or the original number if unchanged.
+func (f *Formatter) formatScientific(amount Amount) (string, Amount) {
+	maxDigits := f.MaxDigits
+	if maxDigits == DefaultDigits {
+		maxDigits = getDigits(amount.CurrencyCode())
+	}
+	mantissa := Amount{currencyCode: amount.currencyCode}
+	mantissa.number.Reduce(&amount.number)
+	exponent := int64(0)
+	if !mantissa.IsZero() {
+		exponent = mantissa.number.NumDigits() - 1 + int64(mantissa.number.Exponent)
+		mantissa.number.Exponent -= int32(exponent)
+	}
+	roundedMantissa := mantissa.roundTo(maxDigits, f.RoundingMode)
+	rounded := !roundedMantissa.Equal(mantissa)
+	if roundedMantissa.number.Cmp(apd.New(10, 0)) >= 0 {
+		// The mantissa was rounded up to 10 (e.g. 9.99 => 10.0).
+		roundedMantissa.number.Exponent--
+		exponent++
+	}
+	roundedAmount := amount
+	if rounded {
+		roundedAmount = roundedMantissa.Shift(int(exponent))
+	}
+	roundedMantissa.number.Reduce(&roundedMantissa.number)
+	numberParts := strings.Split(roundedMantissa.Number(), ".")
+	b := strings.Builder{}
+	b.WriteString(numberParts[0])
+	if len(numberParts) == 2 {
+		b.WriteString(f.format.decimalSeparator)
+		b.WriteString(numberParts[1])
+	}
+	b.WriteString("E")
+	if exponent < 0 {
+		b.WriteString(f.format.minusSign)
+		exponent = -exponent
+	}
+	b.WriteString(strconv.FormatInt(exponent, 10))
+	formatted := f.localizeDigits(b.String())
+
+	return formatted, roundedAmount
 }
 
 // formatCurrency formats the currency for display.
@@ -234,6 +859,21 @@ func (f *Formatter) formatCurrency(currencyCode string) string {
 		}
 	case DisplayCode:
 		formatted = currencyCode
+		if f.CodeStyle == CodeNumeric {
+			if numericCode, ok := GetNumericCode(currencyCode); ok {
+				formatted = numericCode
+			}
+		}
+	case DisplayAuto:
+		if symbol, ok := f.SymbolMap[currencyCode]; ok {
+			formatted = symbol
+		} else {
+			formatted, _ = GetSymbol(currencyCode, f.locale)
+			r, size := utf8.DecodeRuneInString(formatted)
+			if size != len(formatted) || !unicode.IsGraphic(r) {
+				formatted = currencyCode
+			}
+		}
 	default:
 		formatted = ""
 	}
@@ -247,7 +887,7 @@ func (f *Formatter) groupMajorDigits(majorDigits string) string {
 		return majorDigits
 	}
 	numDigits := len(majorDigits)
-	minDigits := int(f.format.minGroupingDigits)
+	minDigits := int(f.MinGroupingDigits)
 	primarySize := int(f.format.primaryGroupingSize)
 	secondarySize := int(f.format.secondaryGroupingSize)
 	if numDigits < (minDigits + primarySize) {
@@ -274,6 +914,25 @@ func (f *Formatter) groupMajorDigits(majorDigits string) string {
 	return majorDigits
 }
 
+// groupMinorDigits groups minor digits in threes, from left to right.
+func (f *Formatter) groupMinorDigits(minorDigits string) string {
+	separator := f.format.groupingSeparator
+	r, _ := utf8.DecodeRuneInString(separator)
+	if !unicode.IsSpace(r) {
+		separator = "\u2009"
+	}
+	var groups []string
+	for i := 0; i < len(minorDigits); i += 3 {
+		high := i + 3
+		if high > len(minorDigits) {
+			high = len(minorDigits)
+		}
+		groups = append(groups, minorDigits[i:high])
+	}
+
+	return strings.Join(groups, separator)
+}
+
 // localizeDigits replaces digits with their localized equivalents.
 func (f *Formatter) localizeDigits(number string) string {
 	if f.format.numberingSystem == numLatn {
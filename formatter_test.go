@@ -18,6 +18,45 @@ func TestFormatter_Locale(t *testing.T) {
 	}
 }
 
+func TestFormatter_Clone(t *testing.T) {
+	locale := currency.NewLocale("en")
+	base := currency.NewFormatter(locale)
+	base.SymbolMap["USD"] = "US$"
+	base.MaxDigits = 2
+
+	clone := base.Clone()
+	if clone == base {
+		t.Fatal("got the same formatter, want a copy")
+	}
+	if clone.Locale() != locale {
+		t.Errorf("got %v, want %v", clone.Locale(), locale)
+	}
+	amount, _ := currency.NewAmount("-1234.567", "USD")
+	got := clone.Format(amount)
+	if got != "-US$1,234.57" {
+		t.Errorf("got %v, want -US$1,234.57", got)
+	}
+
+	// Modifying the clone must not affect the base formatter.
+	clone.AccountingStyle = true
+	clone.SymbolMap["USD"] = "$"
+	got = clone.Format(amount)
+	if got != "($1,234.57)" {
+		t.Errorf("got %v, want ($1,234.57)", got)
+	}
+	got = base.Format(amount)
+	if got != "-US$1,234.57" {
+		t.Errorf("got %v, want -US$1,234.57", got)
+	}
+
+	// Neither must modifying the base formatter affect the clone.
+	base.SymbolMap["USD"] = "USD "
+	got = clone.Format(amount)
+	if got != "($1,234.57)" {
+		t.Errorf("got %v, want ($1,234.57)", got)
+	}
+}
+
 func TestFormatter_Format(t *testing.T) {
 	tests := []struct {
 		number       string
@@ -49,6 +88,7 @@ func TestFormatter_Format(t *testing.T) {
 
 		// Arabic digits.
 		{"12345678.90", "USD", "ar-EG", "\u200f١٢٬٣٤٥٬٦٧٨٫٩٠\u00a0US$"},
+		{"-12345678.90", "USD", "ar-EG", "\u200f\u061c-١٢٬٣٤٥٬٦٧٨٫٩٠\u00a0US$"},
 		// Arabic extended (Persian) digits.
 		{"12345678.90", "USD", "fa", "\u200e$۱۲٬۳۴۵٬۶۷۸٫۹۰"},
 		// Bengali digits.
@@ -122,6 +162,16 @@ func TestFormatter_PlusSign(t *testing.T) {
 
 		{"123.99", "USD", "fr-FR", false, "123,99\u00a0$US"},
 		{"123.99", "USD", "fr-FR", true, "+123,99\u00a0$US"},
+
+		{"123.99", "USD", "sr", false, "123,99\u00a0US$"},
+		{"123.99", "USD", "sr", true, "+123,99\u00a0US$"},
+
+		// RTL locales with and without an explicit negative pattern.
+		// The sign is placed after the leading bidi mark in both cases.
+		{"123.99", "USD", "ar", false, "\u200f123.99\u00a0US$"},
+		{"123.99", "USD", "ar", true, "\u200f\u200e+123.99\u00a0US$"},
+		{"123.99", "USD", "ar-EG", false, "\u200f١٢٣٫٩٩\u00a0US$"},
+		{"123.99", "USD", "ar-EG", true, "\u200f\u061c+١٢٣٫٩٩\u00a0US$"},
 	}
 
 	for _, tt := range tests {
@@ -138,6 +188,103 @@ func TestFormatter_PlusSign(t *testing.T) {
 	}
 }
 
+func TestFormatter_PlusSignZero(t *testing.T) {
+	tests := []struct {
+		number            string
+		localeID          string
+		addPlusSignToZero bool
+		want              string
+	}{
+		{"0", "en", false, "$0.00"},
+		{"0.00", "en", false, "$0.00"},
+		{"-0.00", "en", false, "$0.00"},
+		{"0.01", "en", false, "+$0.01"},
+		{"0", "de-CH", false, "$\u00a00.00"},
+		{"0", "fr-FR", false, "0,00\u00a0$US"},
+
+		{"0", "en", true, "+$0.00"},
+		{"0", "de-CH", true, "$+0.00"},
+		{"0", "fr-FR", true, "+0,00\u00a0$US"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, "USD")
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.AddPlusSign = true
+			formatter.AddPlusSignToZero = tt.addPlusSignToZero
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// FormatNumber follows the same rule.
+	locale := currency.NewLocale("en")
+	formatter := currency.NewFormatter(locale)
+	formatter.AddPlusSign = true
+	got, _ := formatter.FormatNumber("0")
+	if got != "0" {
+		t.Errorf("got %v, want 0", got)
+	}
+	got, _ = formatter.FormatNumber("-0")
+	if got != "0" {
+		t.Errorf("got %v, want 0", got)
+	}
+	formatter.AddPlusSignToZero = true
+	got, _ = formatter.FormatNumber("0")
+	if got != "+0" {
+		t.Errorf("got %v, want +0", got)
+	}
+}
+
+func TestFormatter_ZeroLabel(t *testing.T) {
+	tests := []struct {
+		number            string
+		localeID          string
+		accountingStyle   bool
+		addPlusSign       bool
+		addPlusSignToZero bool
+		want              string
+	}{
+		{"0", "en", false, false, false, "Free"},
+		{"0.00", "en", false, false, false, "Free"},
+		{"-0.00", "en", false, false, false, "Free"},
+		{"-0.00", "en", true, false, false, "Free"},
+		{"0.00", "en", false, true, true, "Free"},
+		{"0.00", "de", false, false, false, "Free"},
+		{"0.01", "en", false, false, false, "$0.01"},
+		{"-0.01", "en", true, false, false, "($0.01)"},
+		// Rounded to zero, but not a true zero.
+		{"0.0000001", "en", false, false, false, "$0.00"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, "USD")
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.ZeroLabel = "Free"
+			formatter.AccountingStyle = tt.accountingStyle
+			formatter.AddPlusSign = tt.addPlusSign
+			formatter.AddPlusSignToZero = tt.addPlusSignToZero
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// No label by default.
+	amount, _ := currency.NewAmount("0", "USD")
+	formatter := currency.NewFormatter(currency.NewLocale("en"))
+	got := formatter.Format(amount)
+	if got != "$0.00" {
+		t.Errorf("got %v, want $0.00", got)
+	}
+}
+
 func TestFormatter_Grouping(t *testing.T) {
 	tests := []struct {
 		number       string
@@ -181,6 +328,132 @@ func TestFormatter_Grouping(t *testing.T) {
 	}
 }
 
+func TestFormatter_MinGroupingDigits(t *testing.T) {
+	tests := []struct {
+		number            string
+		localeID          string
+		minGroupingDigits uint8
+		want              string
+	}{
+		{"999.99", "en", 2, "$999.99"},
+		{"1234.99", "en", 2, "$1234.99"},
+		{"9999.99", "en", 2, "$9999.99"},
+		{"12345.99", "en", 2, "$12,345.99"},
+		{"1234567.99", "en", 2, "$1,234,567.99"},
+
+		{"9999.99", "en", 3, "$9999.99"},
+		{"99999.99", "en", 3, "$99999.99"},
+		{"123456.99", "en", 3, "$123,456.99"},
+
+		// Override the "es" default of 2.
+		{"1234.99", "es", 1, "1.234,99\u00a0US$"},
+		{"1234.99", "es", 2, "1234,99\u00a0US$"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, "USD")
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.MinGroupingDigits = tt.minGroupingDigits
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Confirm that the locale's value is used by default.
+	locale := currency.NewLocale("es")
+	formatter := currency.NewFormatter(locale)
+	if formatter.MinGroupingDigits != 2 {
+		t.Errorf("got %v, want 2", formatter.MinGroupingDigits)
+	}
+}
+
+func TestFormatter_GroupingBoundaries(t *testing.T) {
+	tests := []struct {
+		number   string
+		localeID string
+		want     string
+	}{
+		// "es" has a primary group size of 3, and 2 minimum grouping digits.
+		{"1", "es", "1"},
+		{"123", "es", "123"},
+		{"1234", "es", "1234"},
+		{"12345", "es", "12.345"},
+		{"-1234567.99", "es", "-1.234.567,99"},
+		{"00012345", "es", "12.345"},
+		{"123456789012345678901", "es", "123.456.789.012.345.678.901"},
+		{"-1234567890123456789012", "es", "-1.234.567.890.123.456.789.012"},
+
+		// "hi" has a primary group size of 3, and a secondary group size of 2.
+		{"1", "hi", "1"},
+		{"123", "hi", "123"},
+		{"1234", "hi", "1,234"},
+		{"12345", "hi", "12,345"},
+		{"123456", "hi", "1,23,456"},
+		{"-1234567.99", "hi", "-12,34,567.99"},
+		{"00012345", "hi", "12,345"},
+		{"123456789012345678901", "hi", "12,34,56,78,90,12,34,56,78,901"},
+		{"1234567890123456789012", "hi", "1,23,45,67,89,01,23,45,67,89,012"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			got, err := formatter.FormatNumber(tt.number)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_GroupFraction(t *testing.T) {
+	tests := []struct {
+		number   string
+		localeID string
+		want     string
+	}{
+		{"0.00000123", "en", "$0.000\u2009001\u200923"},
+		{"-0.00000123", "en", "-$0.000\u2009001\u200923"},
+		{"1234.12345678", "en", "$1,234.123\u2009456\u200978"},
+		{"1234.5", "en", "$1,234.50"},
+		{"1234.123", "en", "$1,234.123"},
+		{"1234.1234", "en", "$1,234.123\u20094"},
+		{"1234", "en", "$1,234.00"},
+		{"0.00000123", "de", "0,000\u2009001\u200923\u00a0$"},
+		{"1234.12345678", "fr", "1\u202f234,123\u202f456\u202f78\u00a0$US"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, "USD")
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.GroupFraction = true
+			formatter.MaxDigits = 8
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+
+			// Confirm that the grouped fraction can be parsed back.
+			parsed, err := formatter.Parse(got, "USD")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if cmp, _ := parsed.Cmp(amount); cmp != 0 {
+				t.Errorf("got %v, want %v", parsed, amount)
+			}
+		})
+	}
+}
+
 func TestFormatter_Digits(t *testing.T) {
 	tests := []struct {
 		number       string
@@ -228,6 +501,80 @@ func TestFormatter_Digits(t *testing.T) {
 	}
 }
 
+func TestFormatter_TrimTrailingZeros(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		minDigits    uint8
+		want         string
+	}{
+		{"0.10000000", "BTC", currency.DefaultDigits, "BTC\u00a00.1"},
+		{"0.12345678", "BTC", currency.DefaultDigits, "BTC\u00a00.12345678"},
+		{"0.123456789", "BTC", currency.DefaultDigits, "BTC\u00a00.12345679"},
+		{"1.00000000", "BTC", currency.DefaultDigits, "BTC\u00a01"},
+		{"-0.00010000", "BTC", currency.DefaultDigits, "-BTC\u00a00.0001"},
+		{"0.000000001", "BTC", currency.DefaultDigits, "BTC\u00a00"},
+		{"10.50", "USD", currency.DefaultDigits, "$10.5"},
+		// MinDigits is ignored.
+		{"10.50", "USD", 4, "$10.5"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmountPermissive(tt.number, tt.currencyCode)
+			locale := currency.NewLocale("en")
+			formatter := currency.NewFormatter(locale)
+			formatter.TrimTrailingZeros = true
+			formatter.MinDigits = tt.minDigits
+			formatter.MaxDigits = 8
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_HighPrecision(t *testing.T) {
+	tests := []struct {
+		number    string
+		localeID  string
+		minDigits uint8
+		maxDigits uint8
+		want      string
+	}{
+		// CLF has 4 fraction digits.
+		{"1234567.5", "en", currency.DefaultDigits, 6, "CLF\u00a01,234,567.5000"},
+		{"0.0001", "en", currency.DefaultDigits, 6, "CLF\u00a00.0001"},
+		{"-12.34565", "en", currency.DefaultDigits, currency.DefaultDigits, "-CLF\u00a012.3457"},
+		{"1234567.5", "de-CH", currency.DefaultDigits, 6, "CLF\u00a01’234’567.5000"},
+		{"1234567.5", "hi", currency.DefaultDigits, 6, "CLF\u00a012,34,567.5000"},
+		{"1234567.5", "fr", currency.DefaultDigits, 6, "1\u202f234\u202f567,5000\u00a0CLF"},
+		{"1234567.5", "ar-EG", currency.DefaultDigits, 6, "\u200f١٬٢٣٤٬٥٦٧٫٥٠٠٠\u00a0CLF"},
+
+		// 8 fraction digits, as commonly used by cryptocurrencies.
+		{"1234567.5", "en", 8, 8, "CLF\u00a01,234,567.50000000"},
+		{"0.00000001", "en", 8, 8, "CLF\u00a00.00000001"},
+		{"0.000000015", "en", 8, 8, "CLF\u00a00.00000002"},
+		{"-12.34565", "fr", 8, 8, "-12,34565000\u00a0CLF"},
+		{"1234567.5", "ar-EG", 8, 8, "\u200f١٬٢٣٤٬٥٦٧٫٥٠٠٠٠٠٠٠\u00a0CLF"},
+		{"1234567.12345678", "hi", 0, 8, "CLF\u00a012,34,567.12345678"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, "CLF")
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.MinDigits = tt.minDigits
+			formatter.MaxDigits = tt.maxDigits
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFormatter_RoundingMode(t *testing.T) {
 	tests := []struct {
 		number       string
@@ -268,6 +615,155 @@ func TestFormatter_RoundingMode(t *testing.T) {
 	}
 }
 
+func TestFormatter_FormatWithRounded(t *testing.T) {
+	tests := []struct {
+		number      string
+		maxDigits   uint8
+		want        string
+		wantRounded bool
+	}{
+		{"1234.59", 6, "$1,234.59", false},
+		{"1234.5", 6, "$1,234.50", false},
+		{"1234.123456", 6, "$1,234.123456", false},
+		{"1234.1234567", 6, "$1,234.123457", true},
+
+		{"1234.59", 2, "$1,234.59", false},
+		{"1234.590", 2, "$1,234.59", false},
+		{"1234.591", 2, "$1,234.59", true},
+		{"-1234.596", 2, "-$1,234.60", true},
+		{"1234.59", 0, "$1,235", true},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, "USD")
+			locale := currency.NewLocale("en")
+			formatter := currency.NewFormatter(locale)
+			formatter.MaxDigits = tt.maxDigits
+			got, gotRounded := formatter.FormatWithRounded(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if gotRounded != tt.wantRounded {
+				t.Errorf("rounded: got %v, want %v", gotRounded, tt.wantRounded)
+			}
+		})
+	}
+}
+
+func TestFormatter_ScientificNotation(t *testing.T) {
+	tests := []struct {
+		number      string
+		locale      string
+		want        string
+		wantRounded bool
+	}{
+		{"0.000000012", "en", "$1.2E-8", false},
+		{"-0.000000012", "en", "-$1.2E-8", false},
+		{"1500000", "en", "$1.5E6", false},
+		{"-1500000", "en", "-$1.5E6", false},
+		{"1", "en", "$1E0", false},
+		{"0", "en", "$0E0", false},
+		{"123456789", "en", "$1.234568E8", true},
+		{"-9.9999999", "en", "-$1E1", true},
+		{"0.000000012", "de", "1,2E-8\u00a0$", false},
+		{"-0.000000012", "de", "-1,2E-8\u00a0$", false},
+		{"0.000000012", "fa", "\u200e$۱٫۲E\u200e−۸", false},
+		{"-0.000000012", "fa", "\u200e\u200e−$۱٫۲E\u200e−۸", false},
+		{"0.000000012", "bn", "১.২E-৮\u00a0US$", false},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, "USD")
+			locale := currency.NewLocale(tt.locale)
+			formatter := currency.NewFormatter(locale)
+			formatter.Notation = currency.NotationScientific
+			got, rounded := formatter.FormatWithRounded(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if rounded != tt.wantRounded {
+				t.Errorf("got %v, want %v", rounded, tt.wantRounded)
+			}
+		})
+	}
+}
+
+func TestFormatter_FormatAndRound(t *testing.T) {
+	tests := []struct {
+		number       string
+		maxDigits    uint8
+		roundingMode currency.RoundingMode
+		notation     currency.Notation
+		want         string
+		wantNumber   string
+	}{
+		{"1234.59", 6, currency.RoundHalfUp, currency.NotationStandard, "$1,234.59", "1234.59"},
+		{"1234.5", 6, currency.RoundHalfUp, currency.NotationStandard, "$1,234.50", "1234.5"},
+		{"1234.1234567", 6, currency.RoundHalfUp, currency.NotationStandard, "$1,234.123457", "1234.123457"},
+		{"1234.591", 2, currency.RoundHalfUp, currency.NotationStandard, "$1,234.59", "1234.59"},
+		{"1234.591", 2, currency.RoundUp, currency.NotationStandard, "$1,234.60", "1234.60"},
+		{"-1234.596", 2, currency.RoundHalfUp, currency.NotationStandard, "-$1,234.60", "-1234.60"},
+		{"-0.001", 2, currency.RoundHalfUp, currency.NotationStandard, "-$0.00", "0.00"},
+		{"1234.59", 0, currency.RoundHalfUp, currency.NotationStandard, "$1,235", "1235"},
+		{"123456789", 2, currency.RoundHalfUp, currency.NotationScientific, "$1.23E8", "123000000"},
+		{"-0.0000000125", 1, currency.RoundHalfUp, currency.NotationScientific, "-$1.3E-8", "-0.000000013"},
+		{"0.000000012", 6, currency.RoundHalfUp, currency.NotationScientific, "$1.2E-8", "0.000000012"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, "USD")
+			locale := currency.NewLocale("en")
+			formatter := currency.NewFormatter(locale)
+			formatter.MaxDigits = tt.maxDigits
+			formatter.RoundingMode = tt.roundingMode
+			formatter.Notation = tt.notation
+			got, gotAmount := formatter.FormatAndRound(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if gotAmount.Number() != tt.wantNumber {
+				t.Errorf("got %v, want %v", gotAmount.Number(), tt.wantNumber)
+			}
+			if gotAmount.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", gotAmount.CurrencyCode())
+			}
+		})
+	}
+}
+
+func TestFormatter_FormatScaled(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		digits       uint8
+		localeID     string
+		want         string
+	}{
+		{"10.5", "USD", 4, "en", "$10.5000"},
+		{"1234.12345", "USD", 4, "en", "$1,234.1235"},
+		{"-1234.12345", "USD", 4, "de", "-1.234,1235\u00a0$"},
+		{"10.5", "USD", currency.DefaultDigits, "en", "$10.50"},
+		{"10.5", "JPY", 2, "en", "¥10.50"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewScaledAmount(tt.number, tt.currencyCode, tt.digits)
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			got := formatter.FormatScaled(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			// The formatter itself is unchanged.
+			if formatter.MinDigits != currency.DefaultDigits || formatter.MaxDigits != 6 {
+				t.Errorf("formatter digits modified: got %v and %v", formatter.MinDigits, formatter.MaxDigits)
+			}
+		})
+	}
+}
+
 func TestFormatter_CurrencyDisplay(t *testing.T) {
 	tests := []struct {
 		number          string
@@ -288,6 +784,14 @@ func TestFormatter_CurrencyDisplay(t *testing.T) {
 		{"1234.59", "USD", "sr-Latn", currency.DisplayCode, "1.234,59\u00a0USD"},
 		{"1234.59", "USD", "sr-Latn", currency.DisplayNone, "1.234,59"},
 
+		// DisplayAuto uses the symbol only if it is a single character.
+		{"1234.59", "USD", "en", currency.DisplayAuto, "$1,234.59"},
+		{"1234.59", "EUR", "en", currency.DisplayAuto, "€1,234.59"},
+		{"1234.59", "CHF", "en", currency.DisplayAuto, "CHF\u00a01,234.59"},
+		{"1234.59", "CAD", "en", currency.DisplayAuto, "CAD\u00a01,234.59"},
+		{"1234.59", "USD", "sr-Latn", currency.DisplayAuto, "1.234,59\u00a0USD"},
+		{"1234.59", "EUR", "sr-Latn", currency.DisplayAuto, "1.234,59\u00a0€"},
+
 		// Confirm that any extra spacing around the currency is stripped
 		// even when the negative amount is formatted with the accounting style.
 		{"-1234.59", "USD", "en", currency.DisplayNone, "(1,234.59)"},
@@ -310,42 +814,373 @@ func TestFormatter_CurrencyDisplay(t *testing.T) {
 	}
 }
 
-func TestFormatter_SymbolMap(t *testing.T) {
-	locale := currency.NewLocale("en")
-	formatter := currency.NewFormatter(locale)
-	formatter.SymbolMap["USD"] = "US$"
-	formatter.SymbolMap["EUR"] = "EU"
-
-	amount, _ := currency.NewAmount("6.99", "USD")
-	got := formatter.Format(amount)
-	if got != "US$6.99" {
-		t.Errorf("got %v, want US$6.99", got)
-	}
-
-	amount, _ = currency.NewAmount("6.99", "EUR")
-	got = formatter.Format(amount)
-	if got != "EU\u00a06.99" {
-		t.Errorf("got %v, want EU\u00a06.99", got)
-	}
-}
-
-func TestFormatter_Parse(t *testing.T) {
+func TestFormatter_CodeAsSymbol(t *testing.T) {
 	tests := []struct {
-		s            string
+		number       string
 		currencyCode string
 		localeID     string
 		want         string
 	}{
-		{"$1,234.59", "USD", "en", "1234.59"},
-		{"USD\u00a01,234.59", "USD", "en", "1234.59"},
-		{"1,234.59", "USD", "en", "1234.59"},
-		{"1234.59", "USD", "en", "1234.59"},
-		{"+1234.59", "USD", "en", "1234.59"},
-		{"1234", "USD", "en", "1234"},
+		// CHF has no symbol in these locales, the code is used instead.
+		{"1234", "CHF", "en", "CHF\u00a01,234.00"},
+		{"-1234", "CHF", "en", "-CHF\u00a01,234.00"},
+		{"1234", "CHF", "de-AT", "CHF\u00a01.234,00"},
+		{"-1234", "CHF", "de-CH", "CHF-1’234.00"},
+		{"-1234", "CHF", "en-NL", "CHF\u00a0-1.234,00"},
+		{"1234", "CHF", "de", "1.234,00\u00a0CHF"},
+		{"1234", "CHF", "bn", "১,২৩৪.০০\u00a0CHF"},
+		{"-1234", "CHF", "bn", "-১,২৩৪.০০\u00a0CHF"},
 
-		{"-$1,234.59", "USD", "en", "-1234.59"},
-		{"-USD\u00a01,234.59", "USD", "en", "-1234.59"},
-		{"-1,234.59", "USD", "en", "-1234.59"},
+		// XAU has no symbols at all.
+		{"1234", "XAU", "en", "XAU\u00a01,234.00"},
+		{"1234", "XAU", "de", "1.234,00\u00a0XAU"},
+
+		// Unknown currencies use the code as the symbol.
+		{"1234", "ABC", "en", "ABC\u00a01,234.00"},
+		{"1234", "ABC", "de-AT", "ABC\u00a01.234,00"},
+		{"1234", "ABC", "de", "1.234,00\u00a0ABC"},
+		{"1234", "ABC", "bn", "১,২৩৪.০০\u00a0ABC"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmountPermissive(tt.number, tt.currencyCode)
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+
+			// Confirm that the code is formatted the same way as DisplayCode.
+			formatter.CurrencyDisplay = currency.DisplayCode
+			got = formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("DisplayCode: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_CodeStyle(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		localeID     string
+		codeStyle    currency.CodeStyle
+		want         string
+	}{
+		{"1234.59", "USD", "en", currency.CodeAlpha, "USD\u00a01,234.59"},
+		{"1234.59", "USD", "en", currency.CodeNumeric, "840\u00a01,234.59"},
+		{"-1234.59", "USD", "en", currency.CodeNumeric, "-840\u00a01,234.59"},
+		{"1234.59", "EUR", "de", currency.CodeNumeric, "1.234,59\u00a0978"},
+		{"1234.59", "CHF", "de-CH", currency.CodeNumeric, "756\u00a01’234.59"},
+		{"1234.59", "USD", "bn", currency.CodeNumeric, "১,২৩৪.৫৯\u00a0840"},
+		// Unknown currencies have no numeric code.
+		{"1234.59", "ABC", "en", currency.CodeNumeric, "ABC\u00a01,234.59"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmountPermissive(tt.number, tt.currencyCode)
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.CurrencyDisplay = currency.DisplayCode
+			formatter.CodeStyle = tt.codeStyle
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// The code style only applies to DisplayCode.
+	amount, _ := currency.NewAmount("1234.59", "USD")
+	formatter := currency.NewFormatter(currency.NewLocale("en"))
+	formatter.CodeStyle = currency.CodeNumeric
+	got := formatter.Format(amount)
+	if got != "$1,234.59" {
+		t.Errorf("got %v, want $1,234.59", got)
+	}
+}
+
+func TestFormatter_CodeStyleRoundTrip(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		localeID     string
+	}{
+		// Prefix locales.
+		{"1234.00", "USD", "en"},
+		{"-1234.00", "USD", "en"},
+		{"840840.84", "USD", "en"},
+		{"1234.59", "CHF", "de-CH"},
+		// Suffix locales.
+		{"1234.00", "USD", "de"},
+		{"-1234.00", "USD", "de"},
+		{"840840.84", "USD", "de"},
+		{"1234.59", "EUR", "fr"},
+		{"1234.59", "USD", "bn"},
+		{"1840840.00", "USD", "cs"},
+	}
+	for _, tt := range tests {
+		for _, strict := range []bool{false, true} {
+			t.Run("", func(t *testing.T) {
+				amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
+				locale := currency.NewLocale(tt.localeID)
+				formatter := currency.NewFormatter(locale)
+				formatter.CurrencyDisplay = currency.DisplayCode
+				formatter.CodeStyle = currency.CodeNumeric
+				formatter.StrictParsing = strict
+				formatted := formatter.Format(amount)
+				got, err := formatter.Parse(formatted, tt.currencyCode)
+				if err != nil {
+					t.Errorf("unexpected error for %q: %v", formatted, err)
+				}
+				if got.Number() != tt.number {
+					t.Errorf("got %v, want %v (parsing %q)", got.Number(), tt.number, formatted)
+				}
+			})
+		}
+	}
+}
+
+func TestFormatter_CurrencySpacing(t *testing.T) {
+	restoreEn := currency.SetCurrencySpacing("en", "\u202f", "\u202f")
+	defer restoreEn()
+	restoreDe := currency.SetCurrencySpacing("de", "\u202f", "\u202f")
+	defer restoreDe()
+
+	tests := []struct {
+		number   string
+		localeID string
+		want     string
+	}{
+		{"1234.56", "en", "USD\u202f1,234.56"},
+		{"-1234.56", "en", "-USD\u202f1,234.56"},
+		// The "de" pattern already has a space ("0.00\u00a0¤"),
+		// so the spacing isn't inserted.
+		{"1234.56", "de", "1.234,56\u00a0USD"},
+		// Inherited by child locales.
+		{"1234.56", "en-CA", "USD\u202f1,234.56"},
+	}
+	for _, tt := range tests {
+		for _, strict := range []bool{false, true} {
+			t.Run("", func(t *testing.T) {
+				amount, _ := currency.NewAmount(tt.number, "USD")
+				locale := currency.NewLocale(tt.localeID)
+				formatter := currency.NewFormatter(locale)
+				formatter.CurrencyDisplay = currency.DisplayCode
+				formatter.StrictParsing = strict
+				got := formatter.Format(amount)
+				if got != tt.want {
+					t.Errorf("got %q, want %q", got, tt.want)
+				}
+				parsed, err := formatter.Parse(got, "USD")
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if parsed.Number() != tt.number {
+					t.Errorf("got %v, want %v", parsed.Number(), tt.number)
+				}
+			})
+		}
+	}
+}
+
+func TestFormatter_SymbolMap(t *testing.T) {
+	locale := currency.NewLocale("en")
+	formatter := currency.NewFormatter(locale)
+	formatter.SymbolMap["USD"] = "US$"
+	formatter.SymbolMap["EUR"] = "EU"
+
+	amount, _ := currency.NewAmount("6.99", "USD")
+	got := formatter.Format(amount)
+	if got != "US$6.99" {
+		t.Errorf("got %v, want US$6.99", got)
+	}
+
+	amount, _ = currency.NewAmount("6.99", "EUR")
+	got = formatter.Format(amount)
+	if got != "EU\u00a06.99" {
+		t.Errorf("got %v, want EU\u00a06.99", got)
+	}
+}
+
+func TestFormatter_FormatWithSymbol(t *testing.T) {
+	tests := []struct {
+		number string
+		symbol string
+		locale string
+		want   string
+	}{
+		{"6.99", "US$", "en", "US$6.99"},
+		{"6.99", "EU", "en", "EU\u00a06.99"},
+		{"-6.99", "US$", "en", "-US$6.99"},
+		{"6.99", "US$", "de", "6,99\u00a0US$"},
+		{"6.99", "", "en", "6.99"},
+		{"6.99", "", "de", "6,99"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, "USD")
+			locale := currency.NewLocale(tt.locale)
+			formatter := currency.NewFormatter(locale)
+			formatter.CurrencyDisplay = currency.DisplayNone
+			got := formatter.FormatWithSymbol(amount, tt.symbol)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			// Confirm that the formatter is unchanged.
+			if len(formatter.SymbolMap) != 0 {
+				t.Errorf("got %v, want an empty SymbolMap", formatter.SymbolMap)
+			}
+		})
+	}
+}
+
+func TestFormatter_DisplayAutoSymbolMap(t *testing.T) {
+	locale := currency.NewLocale("en")
+	formatter := currency.NewFormatter(locale)
+	formatter.CurrencyDisplay = currency.DisplayAuto
+	formatter.SymbolMap["CAD"] = "C$"
+
+	amount, _ := currency.NewAmount("6.99", "CAD")
+	got := formatter.Format(amount)
+	if got != "C$6.99" {
+		t.Errorf("got %v, want C$6.99", got)
+	}
+}
+
+func TestFormatter_FormatNumber(t *testing.T) {
+	locale := currency.NewLocale("en")
+	formatter := currency.NewFormatter(locale)
+	_, err := formatter.FormatNumber("INVALID")
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "INVALID" {
+			t.Errorf("got %v, want INVALID", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	tests := []struct {
+		number      string
+		localeID    string
+		addPlusSign bool
+		want        string
+	}{
+		{"1234.59", "en", false, "1,234.59"},
+		{"1234", "en", false, "1,234"},
+		{"1234.5000", "en", false, "1,234.5"},
+		{"1234.1234567", "en", false, "1,234.123457"},
+		{"-1234.59", "en", false, "-1,234.59"},
+		{"1234.59", "en", true, "+1,234.59"},
+		{"0", "en", false, "0"},
+
+		{"1234567.89", "de-CH", false, "1’234’567.89"},
+		{"1234567.89", "hi", false, "12,34,567.89"},
+		{"-1234.59", "sr", false, "-1.234,59"},
+
+		// Localized digits.
+		{"12345678.90", "ar-EG", false, "١٢٬٣٤٥٬٦٧٨٫٩"},
+		{"-12345678.90", "fa", false, "\u200e−۱۲٬۳۴۵٬۶۷۸٫۹"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.AddPlusSign = tt.addPlusSign
+			got, err := formatter.FormatNumber(tt.number)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_FormatPadded(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		localeID     string
+		width        int
+		want         string
+	}{
+		{"1234.59", "USD", "en", 12, "   $1,234.59"},
+		{"1234.59", "USD", "en", 9, "$1,234.59"},
+		{"1234.59", "USD", "en", 4, "$1,234.59"},
+		{"1234.59", "USD", "en", 0, "$1,234.59"},
+
+		// Multibyte symbols are counted as a single character.
+		{"1234.00", "EUR", "en", 12, "   €1,234.00"},
+		{"1234.00", "INR", "en", 12, "   ₹1,234.00"},
+		// Bidi marks are not counted.
+		{"12345678.90", "USD", "fa", 16, "  \u200e$۱۲٬۳۴۵٬۶۷۸٫۹۰"},
+		// Fullwidth symbols are counted as two characters.
+		{"1234", "JPY", "ja", 10, "   ￥1,234"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			got := formatter.FormatPadded(amount, tt.width)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"$1,234.59", 9},
+		{"€1,234.00", 9},
+		{"1.234,00\u00a0€", 10},
+		// Fullwidth and wide characters.
+		{"￥1,234", 7},
+		{"1,234円", 7},
+		{"1,234\u00a0人民币", 12},
+		// Bidi marks.
+		{"\u200e$۱۲٬۳۴۵٬۶۷۸٫۹۰", 14},
+		{"\u200f١٢٬٣٤٥٬٦٧٨٫٩٠\u00a0US$", 17},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := currency.DisplayWidth(tt.s)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_Parse(t *testing.T) {
+	tests := []struct {
+		s            string
+		currencyCode string
+		localeID     string
+		want         string
+	}{
+		{"$1,234.59", "USD", "en", "1234.59"},
+		{"USD\u00a01,234.59", "USD", "en", "1234.59"},
+		{"1,234.59", "USD", "en", "1234.59"},
+		{"1234.59", "USD", "en", "1234.59"},
+		{"+1234.59", "USD", "en", "1234.59"},
+		{"1234", "USD", "en", "1234"},
+
+		{"-$1,234.59", "USD", "en", "-1234.59"},
+		{"-USD\u00a01,234.59", "USD", "en", "-1234.59"},
+		{"-1,234.59", "USD", "en", "-1234.59"},
 		{"-1234.59", "USD", "en", "-1234.59"},
 		{"(1234.59)", "USD", "en", "-1234.59"},
 
@@ -364,6 +1199,12 @@ func TestFormatter_Parse(t *testing.T) {
 		{"US$\u00a0१,२३,४५,६७८.९०", "USD", "ne", "12345678.90"},
 		// Myanmar (Burmese) digits.
 		{"၁၂,၃၄၅,၆၇၈.၉၀\u00a0US$", "USD", "my", "12345678.90"},
+		// Numbers which contain the numeric code.
+		{"840,840.84", "USD", "en", "840840.84"},
+		{"840", "USD", "en", "840"},
+		{"840\u202f000,00\u00a0$US", "USD", "fr", "840000.00"},
+		{"1\u202f840\u00a0$US", "USD", "fr", "1840"},
+		{"978\u202f000\u00a0€", "EUR", "fr", "978000"},
 	}
 
 	for _, tt := range tests {
@@ -386,6 +1227,418 @@ func TestFormatter_Parse(t *testing.T) {
 	}
 }
 
+func TestFormatter_ParseNumericCode(t *testing.T) {
+	tests := []struct {
+		s            string
+		currencyCode string
+		localeID     string
+		want         string
+	}{
+		{"840\u00a01,234.00", "USD", "en", "1234.00"},
+		{"(840\u00a01,234.00)", "USD", "en", "-1234.00"},
+		{"1.234,00\u00a0840", "USD", "de", "1234.00"},
+		{"840-1’234.56", "USD", "de-CH", "-1234.56"},
+		{"840,840.84", "USD", "en", "840840.84"},
+		{"840", "USD", "en", "840"},
+		// Only the spacing emitted by the pattern separates the code.
+		{"840\u202f000,00\u00a0840", "USD", "fr", "840000.00"},
+		{"1\u202f840", "USD", "fr", "1840"},
+		// The code could be a group of the number.
+		{"1\u00a0840", "USD", "cs", "1840"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.AccountingStyle = true
+			formatter.CurrencyDisplay = currency.DisplayCode
+			formatter.CodeStyle = currency.CodeNumeric
+			got, err := formatter.Parse(tt.s, tt.currencyCode)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got.Number() != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_NumericCodeRoundTrip(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+	}{
+		{"840000", "USD"},
+		{"-840000", "USD"},
+		{"978000", "EUR"},
+		{"-978000", "EUR"},
+		{"1840", "USD"},
+		{"-1840", "USD"},
+	}
+	for _, localeID := range []string{"fr", "sv", "ru", "de-CH"} {
+		for _, tt := range tests {
+			for _, codeStyle := range []currency.CodeStyle{currency.CodeAlpha, currency.CodeNumeric} {
+				for _, strict := range []bool{false, true} {
+					t.Run(localeID, func(t *testing.T) {
+						amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
+						locale := currency.NewLocale(localeID)
+						formatter := currency.NewFormatter(locale)
+						formatter.StrictParsing = strict
+						if codeStyle == currency.CodeNumeric {
+							formatter.CurrencyDisplay = currency.DisplayCode
+							formatter.CodeStyle = codeStyle
+						}
+						formatted := formatter.Format(amount)
+						got, err := formatter.Parse(formatted, tt.currencyCode)
+						if err != nil {
+							t.Errorf("unexpected error for %q: %v", formatted, err)
+						}
+						if c, err := got.Cmp(amount); err != nil || c != 0 {
+							t.Errorf("got %v, want %v (parsing %q)", got.Number(), tt.number, formatted)
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+func TestFormatter_ParseEmpty(t *testing.T) {
+	locale := currency.NewLocale("en")
+	formatter := currency.NewFormatter(locale)
+	for _, s := range []string{"", " ", "\t\n", "\u00a0"} {
+		_, err := formatter.Parse(s, "USD")
+		if err != currency.ErrEmptyAmount {
+			t.Errorf("got %v, want currency.ErrEmptyAmount", err)
+		}
+		_, err = currency.Parse(s, "en")
+		if err != currency.ErrEmptyAmount {
+			t.Errorf("got %v, want currency.ErrEmptyAmount", err)
+		}
+	}
+	if currency.ErrEmptyAmount.Error() != "empty amount" {
+		t.Errorf("got %v, want empty amount", currency.ErrEmptyAmount.Error())
+	}
+
+	// A malformed (but not empty) amount is still an InvalidNumberError.
+	_, err := formatter.Parse("$", "USD")
+	if _, ok := err.(currency.InvalidNumberError); !ok {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+}
+
+func TestFormatter_ParsePercent(t *testing.T) {
+	tests := []struct {
+		s        string
+		localeID string
+		want     string
+	}{
+		{"7.5%", "en", "0.075"},
+		{"7.5", "en", "0.075"},
+		{"-2.5%", "en", "-0.025"},
+		{"100%", "en", "1.00"},
+		{"1,234%", "en", "12.34"},
+		{"0.5‰", "en", "0.0005"},
+		{"7,5 %", "de", "0.075"},
+		{"7,5\u00a0%", "de", "0.075"},
+		{"7,5 ‰", "de", "0.0075"},
+		{"7,5\u202f%", "fr", "0.075"},
+		{"1\u202f234,5\u202f%", "fr", "12.345"},
+		{"%7,5", "tr", "0.075"},
+		{"٧٫٥٪", "ar-BH", "0.075"},
+		{"٧٫٥؉", "ar-BH", "0.0075"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			got, err := formatter.ParsePercent(tt.s)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	formatter := currency.NewFormatter(currency.NewLocale("en"))
+	for _, s := range []string{"", "%", "INVALID%", "7.5%%‰"} {
+		_, err := formatter.ParsePercent(s)
+		if e, ok := err.(currency.InvalidNumberError); ok {
+			if e.Number != s {
+				t.Errorf("got %v, want %v", e.Number, s)
+			}
+		} else {
+			t.Errorf("%q: got %T, want currency.InvalidNumberError", s, err)
+		}
+	}
+}
+
+func TestFormatter_RoundTrip(t *testing.T) {
+	localeIDs := []string{
+		"en", "de-CH", "fr", "sr", "es", "hi", "ja",
+		// RTL locales.
+		"ar", "ar-EG", "fa", "he", "ur",
+		// Other numbering systems.
+		"bn", "ne", "my",
+	}
+	numbers := []string{"1234.59", "-1234.59", "0.5", "1234567.891"}
+	displays := []currency.Display{currency.DisplaySymbol, currency.DisplayCode, currency.DisplayNone}
+
+	for _, localeID := range localeIDs {
+		for _, number := range numbers {
+			for _, accountingStyle := range []bool{false, true} {
+				for _, addPlusSign := range []bool{false, true} {
+					for _, display := range displays {
+						amount, _ := currency.NewAmount(number, "EUR")
+						locale := currency.NewLocale(localeID)
+						formatter := currency.NewFormatter(locale)
+						formatter.AccountingStyle = accountingStyle
+						formatter.AddPlusSign = addPlusSign
+						formatter.CurrencyDisplay = display
+						formatted := formatter.Format(amount)
+						got, err := formatter.Parse(formatted, "EUR")
+						if err != nil {
+							t.Errorf("%v: unexpected error parsing %q: %v", localeID, formatted, err)
+						}
+						if !got.Equal(amount) {
+							t.Errorf("%v: got %v, want %v (parsed from %q)", localeID, got, amount, formatted)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestFormatter_ParseParenthesized(t *testing.T) {
+	tests := []struct {
+		s            string
+		currencyCode string
+		localeID     string
+		want         string
+	}{
+		{"($1,234.56)", "USD", "en", "-1234.56"},
+		{"(1,234.56)", "USD", "en", "-1234.56"},
+		{"(USD\u00a01,234.56)", "USD", "en", "-1234.56"},
+		{"(1.234,56\u00a0€)", "EUR", "de", "-1234.56"},
+		{"\u200e(€\u00a01.234,56)", "EUR", "de-AT", "-1234.56"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			got, err := formatter.Parse(tt.s, tt.currencyCode)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got.Number() != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Only fully parenthesized amounts are considered negative.
+	locale := currency.NewLocale("en")
+	formatter := currency.NewFormatter(locale)
+	_, err := formatter.Parse("(1,234.56", "USD")
+	if _, ok := err.(currency.InvalidNumberError); !ok {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+}
+
+func TestFormatter_StrictParsing(t *testing.T) {
+	tests := []struct {
+		s        string
+		localeID string
+		want     string
+	}{
+		{"$1,234,567.89", "en", "1234567.89"},
+		{"$1234567.89", "en", "1234567.89"},
+		{"-$1,234.56", "en", "-1234.56"},
+		{"1.2", "en", "1.2"},
+		{"1.23", "en", "1.23"},
+		{"1.2345", "en", "1.2345"},
+		{"1234.567", "en", "1234.567"},
+		{"1,234.567", "en", "1234.567"},
+		{"12,34,567.89", "hi", "1234567.89"},
+		{"1.234.567,89", "de", "1234567.89"},
+		{"1.234,5", "de", "1234.5"},
+
+		// Ambiguous input.
+		{"1.234", "en", ""},
+		{"1,234", "en", ""},
+		{"123.456", "en", ""},
+		{"1.234", "de", ""},
+		{"1,234", "de", ""},
+
+		// Misplaced grouping separators.
+		{"1.234,56", "en", ""},
+		{"12,34.56", "en", ""},
+		{"1234,567.89", "en", ""},
+		{"1,234,56.78", "en", ""},
+		{",234.56", "en", ""},
+		{"1,234.567,8", "en", ""},
+		{"1,234,567.89", "hi", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.StrictParsing = true
+			got, err := formatter.Parse(tt.s, "USD")
+			if tt.want == "" {
+				if e, ok := err.(currency.InvalidNumberError); ok {
+					if e.Number != tt.s {
+						t.Errorf("got %v, want %v", e.Number, tt.s)
+					}
+				} else {
+					t.Errorf("got %T, want currency.InvalidNumberError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got.Number() != tt.want {
+				t.Errorf("got %v, want %v", got.Number(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_StrictParsingRoundTrip(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		localeID     string
+		want         string
+	}{
+		{"1234567.56", "CZK", "cs", "1\u00a0234\u00a0567,56\u00a0Kč"},
+		{"-1234567.56", "CZK", "cs", "-1\u00a0234\u00a0567,56\u00a0Kč"},
+		{"1234567.56", "PLN", "pl", "1\u00a0234\u00a0567,56\u00a0zł"},
+		{"1234567.56", "RUB", "ru", "1\u00a0234\u00a0567,56\u00a0₽"},
+		{"1234567.56", "EUR", "sk", "1\u00a0234\u00a0567,56\u00a0€"},
+		{"1234567.56", "USD", "sk", "1\u00a0234\u00a0567,56\u00a0$"},
+		{"1234567.56", "EUR", "en", "€1,234,567.56"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.StrictParsing = true
+			formatted := formatter.Format(amount)
+			if formatted != tt.want {
+				t.Errorf("got %q, want %q", formatted, tt.want)
+			}
+			got, err := formatter.Parse(formatted, tt.currencyCode)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got.Number() != tt.number {
+				t.Errorf("got %v, want %v", got.Number(), tt.number)
+			}
+		})
+	}
+}
+
+func TestFormatter_StrictDigits(t *testing.T) {
+	tests := []struct {
+		s            string
+		currencyCode string
+		want         string
+	}{
+		{"$10", "USD", "10"},
+		{"$10.9", "USD", "10.9"},
+		{"$10.99", "USD", "10.99"},
+		{"$10.990", "USD", "10.990"},
+		{"$10.999", "USD", ""},
+		{"-$10.001", "USD", ""},
+
+		{"¥10", "JPY", "10"},
+		{"¥10.5", "JPY", ""},
+
+		{"OMR\u00a010.999", "OMR", "10.999"},
+		{"OMR\u00a010.9999", "OMR", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			locale := currency.NewLocale("en")
+			formatter := currency.NewFormatter(locale)
+			formatter.StrictDigits = true
+			got, err := formatter.Parse(tt.s, tt.currencyCode)
+			if tt.want == "" {
+				if e, ok := err.(currency.InvalidNumberError); ok {
+					if e.Number != tt.s {
+						t.Errorf("got %v, want %v", e.Number, tt.s)
+					}
+				} else {
+					t.Errorf("got %T, want currency.InvalidNumberError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got.Number() != tt.want {
+				t.Errorf("got %v, want %v", got.Number(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	_, err := currency.Parse("1,234.56", "en")
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "" {
+			t.Errorf("got %v, want empty currency code", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+
+	tests := []struct {
+		s                string
+		localeID         string
+		wantNumber       string
+		wantCurrencyCode string
+	}{
+		{"$1,234.56", "en-US", "1234.56", "USD"},
+		{"-$1,234.56", "en-US", "-1234.56", "USD"},
+		{"CA$1,234.56", "en-US", "1234.56", "CAD"},
+		{"€1,234.56", "en", "1234.56", "EUR"},
+		{"USD\u00a01,234.56", "en", "1234.56", "USD"},
+		{"CHF\u00a01,234.56", "en", "1234.56", "CHF"},
+		{"1.234,56\u00a0US$", "sr", "1234.56", "USD"},
+		{"1.234,56\u00a0€", "de", "1234.56", "EUR"},
+		{"1 234,56 EUR", "fr", "1234.56", "EUR"},
+		{"$\u00a01.234,56", "es-AR", "1234.56", "ARS"},
+		{"US$\u00a01.234,56", "es-AR", "1234.56", "USD"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got, err := currency.Parse(tt.s, tt.localeID)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got.Number() != tt.wantNumber {
+				t.Errorf("got %v, want %v", got.Number(), tt.wantNumber)
+			}
+			if got.CurrencyCode() != tt.wantCurrencyCode {
+				t.Errorf("got %v, want %v", got.CurrencyCode(), tt.wantCurrencyCode)
+			}
+		})
+	}
+}
+
 func TestEmptyLocale(t *testing.T) {
 	locale := currency.NewLocale("")
 	formatter := currency.NewFormatter(locale)
@@ -9,6 +9,7 @@ package main
 import (
 	"encoding/json"
 	"encoding/xml"
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -23,6 +24,7 @@ import (
 	"strings"
 	"text/template"
 	"time"
+	"unicode"
 
 	"github.com/bojanz/currency"
 )
@@ -34,6 +36,8 @@ const dataTemplate = `// Code generated by go generate; DO NOT EDIT.
 
 package currency
 
+import "unicode"
+
 // CLDRVersion is the CLDR version from which the data is derived.
 const CLDRVersion = "{{ .CLDRVersion }}"
 
@@ -71,6 +75,14 @@ type currencyFormat struct {
 	minusSign             string
 }
 
+// currencySpacing defines the characters inserted between
+// the currency symbol and the number, if the symbol ends/starts
+// with a letter. Only locales which don't use "\u00a0" are listed.
+type currencySpacing struct {
+	beforeCurrency string
+	afterCurrency  string
+}
+
 // Defined separately to ensure consistent ordering (G10, then others).
 var currencyCodes = []string{
 	// G10 currencies https://en.wikipedia.org/wiki/G10_currencies.
@@ -84,6 +96,11 @@ var currencies = map[string]currencyInfo{
 	{{ export .CurrencyInfo 3 "\t" }}
 }
 
+// Only currencies which aren't KindFiat are listed.
+var currencyKinds = map[string]Kind{
+	{{ export .CurrencyKinds 3 "\t" }}
+}
+
 var currencySymbols = map[string][]symbolInfo{
 	{{ export .SymbolInfo 1 "\t" }}
 }
@@ -92,24 +109,72 @@ var currencyFormats = map[string]currencyFormat{
 	{{ export .Formats 1 "\t" }}
 }
 
+var currencySpacings = map[string]currencySpacing{
+{{- with .CurrencySpacings }}
+	{{ export . 1 "\t" }}
+{{ end -}}
+}
+
 var countryCurrencies = map[string]string{
 	{{ export .CountryCurrencies 5 "\t" }}
 }
 
+var countryCodes = map[string]string{
+	{{ export .CountryCodes 5 "\t" }}
+}
+
 var parentLocales = map[string]string{
 	{{ export .ParentLocales 3 "\t" }}
 }
+
+// eastAsianWide contains the characters with an East Asian Width
+// of "W" (wide) or "F" (fullwidth), as of Unicode {{ .UnicodeVersion }}.
+var eastAsianWide = &unicode.RangeTable{
+	R16: []unicode.Range16{
+{{- range .EastAsianWide.R16 }}
+		{Lo: {{ printf "%#04x" .Lo }}, Hi: {{ printf "%#04x" .Hi }}, Stride: 1},
+{{- end }}
+	},
+	R32: []unicode.Range32{
+{{- range .EastAsianWide.R32 }}
+		{Lo: {{ printf "%#04x" .Lo }}, Hi: {{ printf "%#04x" .Hi }}, Stride: 1},
+{{- end }}
+	},
+}
+`
+
+const codesTemplate = `// Code generated by go generate; DO NOT EDIT.
+
+// Package codes provides constants for all currency codes known to the currency package.
+//
+// The constants are untyped, so they can be passed to any function
+// taking a currency code, e.g. currency.NewAmount("10.99", codes.USD).
+package codes
+
+const (
+{{- range .CurrencyCodes }}
+	{{ . }} = "{{ . }}"
+{{- end }}
+)
 `
 
 type currencyInfo struct {
 	numericCode string
 	digits      uint8
+	kind        currencyKind
 }
 
 func (c currencyInfo) GoString() string {
 	return fmt.Sprintf("{%q, %d}", c.numericCode, int(c.digits))
 }
 
+// currencyKind is the name of a currency.Kind constant (e.g. "KindFund").
+type currencyKind string
+
+func (k currencyKind) GoString() string {
+	return string(k)
+}
+
 type symbolInfo struct {
 	symbol  string
 	locales []string
@@ -157,11 +222,25 @@ type currencyFormat struct {
 	minusSign             string
 }
 
+type currencySpacing struct {
+	beforeCurrency string
+	afterCurrency  string
+}
+
+func (s currencySpacing) GoString() string {
+	return fmt.Sprintf("{%q, %q}", s.beforeCurrency, s.afterCurrency)
+}
+
 func (f currencyFormat) GoString() string {
 	return fmt.Sprintf("{%q, %q, %d, %d, %d, %d, %q, %q, %q, %q}", f.standardPattern, f.accountingPattern, f.numberingSystem, f.minGroupingDigits, f.primaryGroupingSize, f.secondaryGroupingSize, f.decimalSeparator, f.groupingSeparator, f.plusSign, f.minusSign)
 }
 
 func main() {
+	var localesFlag, currenciesFlag string
+	flag.StringVar(&localesFlag, "locales", "", "Comma-separated list of locales (or languages) to include, e.g. \"en,de,fr-CH\". Defaults to all.")
+	flag.StringVar(&currenciesFlag, "currencies", "", "Comma-separated list of currency codes to include, e.g. \"EUR,USD\". Defaults to all.")
+	flag.Parse()
+
 	err := os.Mkdir(assetDir, 0755)
 	if err != nil {
 		log.Fatal(err)
@@ -182,12 +261,25 @@ func main() {
 		log.Fatal(err)
 	}
 
+	log.Println("Fetching Unicode data...")
+	eastAsianWide, err := fetchEastAsianWide(unicode.Version)
+	if err != nil {
+		os.RemoveAll(assetDir)
+		log.Fatal(err)
+	}
+
 	log.Println("Processing...")
 	locales, err := collectLocales(assetDir)
 	if err != nil {
 		os.RemoveAll(assetDir)
 		log.Fatal(err)
 	}
+	if localesFlag != "" {
+		locales = filterLocales(locales, strings.Split(localesFlag, ","))
+	}
+	if currenciesFlag != "" {
+		currencies = filterCurrencies(currencies, strings.Split(currenciesFlag, ","))
+	}
 	symbols, err := generateSymbols(currencies, locales, assetDir)
 	if err != nil {
 		os.RemoveAll(assetDir)
@@ -198,11 +290,26 @@ func main() {
 		os.RemoveAll(assetDir)
 		log.Fatal(err)
 	}
+	spacings, err := generateCurrencySpacings(locales, assetDir)
+	if err != nil {
+		os.RemoveAll(assetDir)
+		log.Fatal(err)
+	}
 	countryCurrencies, err := generateCountryCurrencies(assetDir)
 	if err != nil {
 		os.RemoveAll(assetDir)
 		log.Fatal(err)
 	}
+	for countryCode, currencyCode := range countryCurrencies {
+		if _, ok := currencies[currencyCode]; !ok {
+			delete(countryCurrencies, countryCode)
+		}
+	}
+	countryCodes, err := generateCountryCodes(countryCurrencies, assetDir)
+	if err != nil {
+		os.RemoveAll(assetDir)
+		log.Fatal(err)
+	}
 	parentLocales, err := generateParentLocales(locales, assetDir)
 	if err != nil {
 		os.RemoveAll(assetDir)
@@ -210,17 +317,23 @@ func main() {
 	}
 
 	var currencyCodes []string
-	for currencyCode := range currencies {
+	currencyKinds := make(map[string]currencyKind)
+	for currencyCode, info := range currencies {
 		currencyCodes = append(currencyCodes, currencyCode)
+		if info.kind != "" {
+			currencyKinds[currencyCode] = info.kind
+		}
 	}
 	sort.Strings(currencyCodes)
 
-	g10Currencies := []string{
+	g10 := []string{
 		"AUD", "CAD", "CHF", "EUR", "GBP", "JPY", "NOK", "NZD", "SEK", "USD",
 	}
-	var otherCurrencies []string
+	var g10Currencies, otherCurrencies []string
 	for _, currencyCode := range currencyCodes {
-		if !contains(g10Currencies, currencyCode) {
+		if contains(g10, currencyCode) {
+			g10Currencies = append(g10Currencies, currencyCode)
+		} else {
 			otherCurrencies = append(otherCurrencies, currencyCode)
 		}
 	}
@@ -246,24 +359,64 @@ func main() {
 		G10Currencies     []string
 		OtherCurrencies   []string
 		CurrencyInfo      map[string]*currencyInfo
+		CurrencyKinds     map[string]currencyKind
 		SymbolInfo        map[string]symbolInfoSlice
 		Formats           map[string]currencyFormat
+		CurrencySpacings  map[string]currencySpacing
 		CountryCurrencies map[string]string
+		CountryCodes      map[string]string
 		ParentLocales     map[string]string
+		UnicodeVersion    string
+		EastAsianWide     runeRanges
 	}{
 		CLDRVersion:       CLDRVersion,
 		G10Currencies:     g10Currencies,
 		OtherCurrencies:   otherCurrencies,
 		CurrencyInfo:      currencies,
+		CurrencyKinds:     currencyKinds,
 		SymbolInfo:        symbols,
 		Formats:           formats,
+		CurrencySpacings:  spacings,
 		CountryCurrencies: countryCurrencies,
+		CountryCodes:      countryCodes,
 		ParentLocales:     parentLocales,
+		UnicodeVersion:    unicode.Version,
+		EastAsianWide:     eastAsianWide,
 	})
 
+	if err := generateCodes(currencyCodes); err != nil {
+		os.RemoveAll(assetDir)
+		log.Fatal(err)
+	}
+
 	log.Println("Done.")
 }
 
+// generateCodes generates the codes package, with a constant for each currency code.
+func generateCodes(currencyCodes []string) error {
+	os.Remove("codes/codes.go")
+	f, err := os.Create("codes/codes.go")
+	if err != nil {
+		return fmt.Errorf("generateCodes: %w", err)
+	}
+	defer f.Close()
+
+	t, err := template.New("codes").Parse(codesTemplate)
+	if err != nil {
+		return fmt.Errorf("generateCodes: %w", err)
+	}
+	err = t.Execute(f, struct {
+		CurrencyCodes []string
+	}{
+		CurrencyCodes: currencyCodes,
+	})
+	if err != nil {
+		return fmt.Errorf("generateCodes: %w", err)
+	}
+
+	return nil
+}
+
 // fetchCLDR fetches the CLDR data from GitHub and returns its version.
 //
 // The JSON version of the data is used because it is more convenient
@@ -322,17 +475,111 @@ func fetchISO() (map[string]*currencyInfo, error) {
 
 	currencies := make(map[string]*currencyInfo, 170)
 	for _, entry := range aux.Table[0].Entry {
-		if entry.Code == "" || entry.Number == "" || entry.Digits == "N.A." {
+		if entry.Code == "" || entry.Number == "" {
+			continue
+		}
+		if entry.Digits == "N.A." && (entry.Code == "XTS" || entry.Code == "XXX") {
+			// Skip the codes reserved for testing and for "no currency".
+			// Other codes without minor units (precious metals, XDR, etc)
+			// are kept, and assumed to have 2 fraction digits.
 			continue
 		}
 
+		var kind currencyKind
+		if contains([]string{"XAG", "XAU", "XPD", "XPT"}, entry.Code) {
+			kind = "KindMetal"
+		} else if entry.Name.IsFund || entry.Digits == "N.A." {
+			// Units of account without minor units (e.g. XDR) are grouped with funds.
+			kind = "KindFund"
+		}
 		digits := parseDigits(entry.Digits, 2)
-		currencies[entry.Code] = &currencyInfo{entry.Number, digits}
+		currencies[entry.Code] = &currencyInfo{entry.Number, digits, kind}
 	}
 
 	return currencies, nil
 }
 
+// runeRange is an inclusive range of runes.
+type runeRange struct {
+	Lo rune
+	Hi rune
+}
+
+// runeRanges holds rune ranges, split like in a unicode.RangeTable.
+type runeRanges struct {
+	R16 []runeRange
+	R32 []runeRange
+}
+
+// fetchEastAsianWide fetches the wide ("W") and fullwidth ("F") characters
+// from the EastAsianWidth.txt file of the given Unicode version.
+//
+// The same version as Go's unicode package should be used, to match
+// the categories used together with the data (e.g. unicode.Mn).
+func fetchEastAsianWide(version string) (runeRanges, error) {
+	data, err := fetchURL("https://www.unicode.org/Public/" + version + "/ucd/EastAsianWidth.txt")
+	if err != nil {
+		return runeRanges{}, fmt.Errorf("fetchEastAsianWide: %w", err)
+	}
+	var ranges []runeRange
+	for _, line := range strings.Split(string(data), "\n") {
+		// Format: "1100..115F;W  # Lo  [96] HANGUL CHOSEONG KIYEOK..".
+		if i := strings.IndexByte(line, '#'); i != -1 {
+			line = line[:i]
+		}
+		fields := strings.Split(line, ";")
+		if len(fields) != 2 {
+			continue
+		}
+		property := strings.TrimSpace(fields[1])
+		if property != "W" && property != "F" {
+			continue
+		}
+		bounds := strings.Split(strings.TrimSpace(fields[0]), "..")
+		lo, err := strconv.ParseUint(bounds[0], 16, 32)
+		if err != nil {
+			return runeRanges{}, fmt.Errorf("fetchEastAsianWide: %w", err)
+		}
+		hi := lo
+		if len(bounds) == 2 {
+			hi, err = strconv.ParseUint(bounds[1], 16, 32)
+			if err != nil {
+				return runeRanges{}, fmt.Errorf("fetchEastAsianWide: %w", err)
+			}
+		}
+		ranges = append(ranges, runeRange{rune(lo), rune(hi)})
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Lo < ranges[j].Lo
+	})
+
+	// Merge adjacent ranges, and split them at the 16-bit boundary.
+	var merged []runeRange
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && merged[n-1].Hi+1 >= r.Lo {
+			if r.Hi > merged[n-1].Hi {
+				merged[n-1].Hi = r.Hi
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	var result runeRanges
+	for _, r := range merged {
+		if r.Lo <= 0xffff && r.Hi > 0xffff {
+			result.R16 = append(result.R16, runeRange{r.Lo, 0xffff})
+			r.Lo = 0x10000
+		}
+		if r.Hi <= 0xffff {
+			result.R16 = append(result.R16, r)
+		} else {
+			result.R32 = append(result.R32, r)
+		}
+	}
+
+	return result, nil
+}
+
 func fetchURL(url string) ([]byte, error) {
 	client := http.Client{Timeout: 15 * time.Second}
 	resp, err := client.Get(url)
@@ -374,6 +621,35 @@ func collectLocales(dir string) ([]string, error) {
 	return locales, nil
 }
 
+// filterLocales filters the locales down to the selected ones.
+//
+// A locale is kept if its ID or its language is selected (e.g. "sr" selects
+// both "sr" and "sr-Latn"). The "en" locale is always kept, since it is
+// the fallback for all other locales.
+func filterLocales(locales []string, selected []string) []string {
+	var filtered []string
+	for _, locale := range locales {
+		language := strings.Split(locale, "-")[0]
+		if locale == "en" || contains(selected, locale) || contains(selected, language) {
+			filtered = append(filtered, locale)
+		}
+	}
+
+	return filtered
+}
+
+// filterCurrencies filters the currencies down to the selected ones.
+func filterCurrencies(currencies map[string]*currencyInfo, selected []string) map[string]*currencyInfo {
+	filtered := make(map[string]*currencyInfo, len(selected))
+	for currencyCode, info := range currencies {
+		if contains(selected, currencyCode) {
+			filtered[currencyCode] = info
+		}
+	}
+
+	return filtered
+}
+
 // generateCountryCurrencies generates the map of country codes to currency codes.
 func generateCountryCurrencies(dir string) (map[string]string, error) {
 	data, err := os.ReadFile(dir + "/cldr-json/cldr-core/supplemental/currencyData.json")
@@ -426,6 +702,44 @@ func generateCountryCurrencies(dir string) (map[string]string, error) {
 	return countryCurrencies, nil
 }
 
+// generateCountryCodes generates the map of alpha-3 and numeric country codes
+// to alpha-2 country codes, for countries which have a currency.
+func generateCountryCodes(countryCurrencies map[string]string, dir string) (map[string]string, error) {
+	data, err := os.ReadFile(dir + "/cldr-json/cldr-core/supplemental/codeMappings.json")
+	if err != nil {
+		return nil, fmt.Errorf("generateCountryCodes: %w", err)
+	}
+
+	aux := struct {
+		Supplemental struct {
+			CodeMappings map[string]struct {
+				Numeric string `json:"_numeric"`
+				Alpha3  string `json:"_alpha3"`
+			}
+		}
+	}{}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return nil, fmt.Errorf("generateCountryCodes: %w", err)
+	}
+
+	countryCodes := make(map[string]string)
+	for countryCode, mapping := range aux.Supplemental.CodeMappings {
+		if _, ok := countryCurrencies[countryCode]; !ok {
+			continue
+		}
+		if mapping.Numeric == "" {
+			// Not an officially assigned ISO 3166-1 code (e.g. AC, XK).
+			continue
+		}
+		countryCodes[mapping.Numeric] = countryCode
+		if mapping.Alpha3 != "" {
+			countryCodes[mapping.Alpha3] = countryCode
+		}
+	}
+
+	return countryCodes, nil
+}
+
 // generateSymbols generates currency symbols for all locales.
 //
 // Symbols are grouped by locale, and deduplicated by parent.
@@ -720,6 +1034,82 @@ func readFormat(dir string, locale string) (currencyFormat, error) {
 	return format, nil
 }
 
+// generateCurrencySpacings generates currency spacings for all locales.
+//
+// Only spacings which differ from the default ("\u00a0")
+// or from the parent locale are kept.
+func generateCurrencySpacings(locales []string, dir string) (map[string]currencySpacing, error) {
+	spacings := make(map[string]currencySpacing)
+	for _, locale := range locales {
+		spacing, err := readCurrencySpacing(dir, locale)
+		if err != nil {
+			return nil, fmt.Errorf("generateCurrencySpacings: %w", err)
+		}
+		spacings[locale] = spacing
+	}
+
+	defaultSpacing := currencySpacing{"\u00a0", "\u00a0"}
+	var deleteLocales []string
+	for localeID, spacing := range spacings {
+		locale := currency.NewLocale(localeID)
+		parentSpacing := defaultSpacing
+		for parent := locale.GetParent(); !parent.IsEmpty(); parent = parent.GetParent() {
+			if s, ok := spacings[parent.String()]; ok {
+				parentSpacing = s
+				break
+			}
+		}
+		if spacing == parentSpacing {
+			deleteLocales = append(deleteLocales, localeID)
+		}
+	}
+	for _, localeID := range deleteLocales {
+		delete(spacings, localeID)
+	}
+
+	return spacings, nil
+}
+
+// readCurrencySpacing reads the given locale's currency spacing from CLDR data.
+func readCurrencySpacing(dir string, locale string) (currencySpacing, error) {
+	filename := fmt.Sprintf("%v/cldr-json/cldr-numbers-full/main/%v/numbers.json", dir, locale)
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return currencySpacing{}, fmt.Errorf("readCurrencySpacing: %w", err)
+	}
+
+	type cldrSpacing struct {
+		CurrencySpacing struct {
+			BeforeCurrency struct {
+				InsertBetween string
+			}
+			AfterCurrency struct {
+				InsertBetween string
+			}
+		}
+	}
+	aux := struct {
+		Main map[string]map[string]map[string]json.RawMessage
+	}{}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return currencySpacing{}, fmt.Errorf("readCurrencySpacing: %w", err)
+	}
+	numbers := aux.Main[locale]["numbers"]
+	var numSystem string
+	if err := json.Unmarshal(numbers["defaultNumberingSystem"], &numSystem); err != nil {
+		return currencySpacing{}, fmt.Errorf("readCurrencySpacing: %w", err)
+	}
+	spacing := cldrSpacing{}
+	if err := json.Unmarshal(numbers["currencyFormats-numberSystem-"+numSystem], &spacing); err != nil {
+		return currencySpacing{}, fmt.Errorf("readCurrencySpacing: %w", err)
+	}
+
+	return currencySpacing{
+		beforeCurrency: spacing.CurrencySpacing.BeforeCurrency.InsertBetween,
+		afterCurrency:  spacing.CurrencySpacing.AfterCurrency.InsertBetween,
+	}, nil
+}
+
 // processPattern processes the pattern.
 func processPattern(pattern string) string {
 	// Strip the grouping info.
@@ -43,6 +43,14 @@ func NewLocale(id string) Locale {
 	return locale
 }
 
+// CanonicalLocaleID returns the canonical form of a locale ID.
+//
+// For example, "SR_rs_LATN" and "sr-Latn-RS" both become "sr-Latn-RS".
+// Useful for comparing user-provided IDs, or using them as cache keys.
+func CanonicalLocaleID(id string) string {
+	return NewLocale(id).String()
+}
+
 // String returns the string representation of l.
 func (l Locale) String() string {
 	b := strings.Builder{}
@@ -38,6 +38,30 @@ func TestNewLocale(t *testing.T) {
 	}
 }
 
+func TestCanonicalLocaleID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"", ""},
+		{"de", "de"},
+		{"DE_ch", "de-CH"},
+		{"es-419", "es-419"},
+		{"  yue-hans ", "yue-Hans"},
+		{"sr_RS_latn", "sr-Latn-RS"},
+		{"sr-Latn-RS", "sr-Latn-RS"},
+		{"ca-ES-VALENCIA", "ca-ES"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got := currency.CanonicalLocaleID(tt.id)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestLocale_String(t *testing.T) {
 	tests := []struct {
 		locale currency.Locale